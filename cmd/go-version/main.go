@@ -0,0 +1,405 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+// Command go-version compares, sorts, filters, bumps and validates
+// versions the same way as the go-version library, for use from shell
+// scripts and CI jobs.
+//
+// Usage:
+//
+//	go-version compare [-json] A OP B
+//	go-version sort [-json] [-r] < versions
+//	go-version filter [-json] CONSTRAINT < versions
+//	go-version bump [-json] [-channel NAME] major|minor|patch|prerelease VERSION
+//	go-version validate [-json] [VERSION...]
+//
+// compare exits with status 0 if "A OP B" holds and 1 otherwise, with OP
+// one of =, !=, <, <=, > and >=. filter exits with status 1 if no version
+// matches, and validate if any version is not strict SemVer. Usage and
+// parse errors exit with status 2.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	version "github.com/hashicorp/go-version"
+)
+
+const (
+	exitOK    = 0
+	exitFalse = 1
+	exitError = 2
+)
+
+const usage = `usage: go-version <command> [arguments]
+
+commands:
+  compare [-json] A OP B     exit 0 if A OP B holds, with OP one of = != < <= > >=
+  sort [-json] [-r]          sort the versions read from stdin
+  filter [-json] CONSTRAINT  print the versions read from stdin that match
+  bump [-json] [-channel NAME] major|minor|patch|prerelease VERSION
+                             print the next version
+  validate [-json] [VERSION...]
+                             check the versions, or those read from stdin,
+                             are strict SemVer
+`
+
+type command func(args []string, stdin io.Reader, stdout, stderr io.Writer) int
+
+var commands = map[string]command{
+	"compare":  runCompare,
+	"sort":     runSort,
+	"filter":   runFilter,
+	"bump":     runBump,
+	"validate": runValidate,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitError
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "go-version: unknown command %q\n\n%s", args[0], usage)
+		return exitError
+	}
+
+	return cmd(args[1:], stdin, stdout, stderr)
+}
+
+// newFlagSet returns the flags of a command, with the -json flag common to
+// all commands.
+func newFlagSet(name string, stderr io.Writer) (*flag.FlagSet, *bool) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	jsonOutput := fs.Bool("json", false, "print the result as JSON")
+
+	return fs, jsonOutput
+}
+
+func runCompare(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs, jsonOutput := newFlagSet("compare", stderr)
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+	if fs.NArg() != 3 {
+		fmt.Fprintln(stderr, "usage: go-version compare [-json] A OP B")
+		return exitError
+	}
+
+	a, err := version.NewVersion(fs.Arg(0))
+	if err != nil {
+		return fail(stderr, err)
+	}
+	b, err := version.NewVersion(fs.Arg(2))
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	cmp := a.Compare(b)
+	var holds bool
+	switch op := fs.Arg(1); op {
+	case "=", "==":
+		holds = cmp == 0
+	case "!=":
+		holds = cmp != 0
+	case "<":
+		holds = cmp < 0
+	case "<=":
+		holds = cmp <= 0
+	case ">":
+		holds = cmp > 0
+	case ">=":
+		holds = cmp >= 0
+	default:
+		return fail(stderr, fmt.Errorf("unknown operator %q", op))
+	}
+
+	if *jsonOutput {
+		writeJSON(stdout, struct {
+			Compare int  `json:"compare"`
+			Result  bool `json:"result"`
+		}{cmp, holds})
+	}
+
+	if !holds {
+		return exitFalse
+	}
+	return exitOK
+}
+
+func runSort(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs, jsonOutput := newFlagSet("sort", stderr)
+	reverse := fs.Bool("r", false, "sort from highest to lowest")
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+
+	lines, err := readLines(stdin)
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	versions := make(version.Collection, len(lines))
+	for i, line := range lines {
+		versions[i], err = version.NewVersion(line)
+		if err != nil {
+			return fail(stderr, err)
+		}
+	}
+
+	if *reverse {
+		sort.Stable(sort.Reverse(versions))
+	} else {
+		sort.Stable(versions)
+	}
+
+	writeVersions(stdout, versions, *jsonOutput)
+
+	return exitOK
+}
+
+func runFilter(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs, jsonOutput := newFlagSet("filter", stderr)
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(stderr, "usage: go-version filter [-json] CONSTRAINT")
+		return exitError
+	}
+
+	cs, err := version.ParseConstraint(fs.Arg(0))
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	lines, err := readLines(stdin)
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	// Lines that are not versions never match, like with grep
+	var matches version.Collection
+	for _, line := range lines {
+		if v, err := version.NewVersion(line); err == nil && cs.Check(v) {
+			matches = append(matches, v)
+		}
+	}
+
+	writeVersions(stdout, matches, *jsonOutput)
+
+	if len(matches) == 0 {
+		return exitFalse
+	}
+	return exitOK
+}
+
+func runBump(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs, jsonOutput := newFlagSet("bump", stderr)
+	channel := fs.String("channel", "rc", "pre-release channel of the prerelease bump")
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+	if fs.NArg() != 2 {
+		fmt.Fprintln(stderr, "usage: go-version bump [-json] [-channel NAME] major|minor|patch|prerelease VERSION")
+		return exitError
+	}
+
+	v, err := version.NewVersion(fs.Arg(1))
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	var next *version.Version
+	switch part := fs.Arg(0); part {
+	case "major":
+		next = v.BumpMajor()
+	case "minor":
+		next = v.BumpMinor()
+	case "patch":
+		next = v.BumpPatch()
+	case "prerelease":
+		if next, err = v.BumpPrerelease(*channel); err != nil {
+			return fail(stderr, err)
+		}
+	default:
+		return fail(stderr, fmt.Errorf("unknown part %q", part))
+	}
+
+	if *jsonOutput {
+		writeJSON(stdout, struct {
+			Version string `json:"version"`
+		}{next.String()})
+	} else {
+		fmt.Fprintln(stdout, next)
+	}
+
+	return exitOK
+}
+
+func runValidate(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs, jsonOutput := newFlagSet("validate", stderr)
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+
+	inputs := fs.Args()
+	if len(inputs) == 0 {
+		var err error
+		if inputs, err = readLines(stdin); err != nil {
+			return fail(stderr, err)
+		}
+	}
+
+	type result struct {
+		Version string `json:"version"`
+		Valid   bool   `json:"valid"`
+		Error   string `json:"error,omitempty"`
+	}
+
+	code := exitOK
+	results := make([]result, len(inputs))
+	for i, input := range inputs {
+		results[i] = result{Version: input, Valid: true}
+		if err := validateStrict(input); err != nil {
+			results[i].Valid = false
+			results[i].Error = err.Error()
+			code = exitFalse
+		}
+	}
+
+	if *jsonOutput {
+		writeJSON(stdout, results)
+	} else {
+		for _, r := range results {
+			if r.Valid {
+				fmt.Fprintf(stdout, "%s: valid\n", r.Version)
+			} else {
+				fmt.Fprintf(stdout, "%s: %s\n", r.Version, r.Error)
+			}
+		}
+	}
+
+	return code
+}
+
+// validateStrict returns an error if s is not a valid SemVer 2.0.0
+// version, which NewSemver alone is more lenient about.
+func validateStrict(s string) error {
+	v, err := version.NewSemver(s)
+	if err != nil {
+		return err
+	}
+
+	core := s
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		core = core[:i]
+	}
+	if strings.HasPrefix(core, "v") {
+		return fmt.Errorf("version must not have a %q prefix", "v")
+	}
+
+	segments := strings.Split(core, ".")
+	if len(segments) != 3 {
+		return fmt.Errorf("version must have 3 segments, got %d", len(segments))
+	}
+	for _, segment := range segments {
+		if hasLeadingZero(segment) {
+			return fmt.Errorf("segment %q must not have leading zeros", segment)
+		}
+	}
+
+	if v.Prerelease() != "" {
+		for _, id := range strings.Split(v.Prerelease(), ".") {
+			if !isIdentifier(id) {
+				return fmt.Errorf("pre-release identifier %q must only contain [0-9A-Za-z-]", id)
+			}
+			if strings.Trim(id, "0123456789") == "" && hasLeadingZero(id) {
+				return fmt.Errorf("pre-release identifier %q must not have leading zeros", id)
+			}
+		}
+	}
+	if v.Metadata() != "" {
+		for _, id := range strings.Split(v.Metadata(), ".") {
+			if !isIdentifier(id) {
+				return fmt.Errorf("metadata identifier %q must only contain [0-9A-Za-z-]", id)
+			}
+		}
+	}
+
+	return nil
+}
+
+func hasLeadingZero(s string) bool {
+	return len(s) > 1 && s[0] == '0'
+}
+
+// isIdentifier tests if s is a non-empty pre-release or metadata
+// identifier made of ASCII alphanumerics and hyphens.
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !(r >= '0' && r <= '9' || r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' || r == '-') {
+			return false
+		}
+	}
+
+	return true
+}
+
+func readLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines, scanner.Err()
+}
+
+// writeVersions prints versions as given on input, one per line, or as a
+// JSON list.
+func writeVersions(w io.Writer, versions version.Collection, jsonOutput bool) {
+	originals := make([]string, len(versions))
+	for i, v := range versions {
+		originals[i] = v.Original()
+	}
+
+	if jsonOutput {
+		writeJSON(w, originals)
+		return
+	}
+
+	for _, o := range originals {
+		fmt.Fprintln(w, o)
+	}
+}
+
+func writeJSON(w io.Writer, v interface{}) {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
+
+func fail(stderr io.Writer, err error) int {
+	fmt.Fprintf(stderr, "go-version: %s\n", err)
+	return exitError
+}
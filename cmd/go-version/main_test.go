@@ -0,0 +1,77 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	cases := []struct {
+		args   string
+		stdin  string
+		code   int
+		stdout string
+	}{
+		{"compare 1.2.3 < 1.10.0", "", exitOK, ""},
+		{"compare 1.2.3 >= 1.10.0", "", exitFalse, ""},
+		{"compare 1.0.0-beta < 1.0.0", "", exitOK, ""},
+		{"compare v1.0 = 1.0.0+meta", "", exitOK, ""},
+		{"compare 1.0 != 1.0.0", "", exitFalse, ""},
+		{"compare 1.0 ~> 1.0", "", exitError, ""},
+		{"compare 1.0 <", "", exitError, ""},
+		{"compare -json 2.0 > 1.0", "", exitOK, "{\n  \"compare\": 1,\n  \"result\": true\n}\n"},
+
+		{"sort", "1.10.0\nv1.2\n\n1.2.0-rc.1\n1.9\n", exitOK, "1.2.0-rc.1\nv1.2\n1.9\n1.10.0\n"},
+		{"sort -r", "1.10.0\n1.2\n1.9\n", exitOK, "1.10.0\n1.9\n1.2\n"},
+		{"sort -json", "2\n1\n", exitOK, "[\n  \"1\",\n  \"2\"\n]\n"},
+		{"sort", "1.0\nlatest\n", exitError, ""},
+
+		{"filter ~>1.2", "1.1.0\n1.2.5\nlatest\n1.3.0\n2.0.0\n", exitOK, "1.2.5\n1.3.0\n"},
+		{"filter >=3.0", "1.1.0\n2.0.0\n", exitFalse, ""},
+		{"filter -json >=3.0", "1.1.0\n", exitFalse, "[]\n"},
+		{"filter =>1.0", "1.1.0\n", exitError, ""},
+
+		{"bump major 1.4.2", "", exitOK, "2.0.0\n"},
+		{"bump minor v1.4.2", "", exitOK, "1.5.0\n"},
+		{"bump patch 1.4.2-rc.1", "", exitOK, "1.4.2\n"},
+		{"bump prerelease 1.4.2", "", exitOK, "1.4.3-rc.1\n"},
+		{"bump -channel beta prerelease 1.4.3-beta.1", "", exitOK, "1.4.3-beta.2\n"},
+		{"bump -json major 1.0", "", exitOK, "{\n  \"version\": \"2.0.0\"\n}\n"},
+		{"bump build 1.0", "", exitError, ""},
+		{"bump prerelease 1.4.3-rc.x", "", exitError, ""},
+		{"bump -channel rc_1 prerelease 1.4.2", "", exitError, ""},
+		{"bump -channel beta prerelease 1.4.3-rc.1", "", exitError, ""},
+
+		{"validate 1.2.3 1.2.3-rc.1+build.5", "", exitOK, "1.2.3: valid\n1.2.3-rc.1+build.5: valid\n"},
+		{"validate", "v1.2.3\n1.2\n", exitFalse,
+			"v1.2.3: version must not have a \"v\" prefix\n1.2: version must have 3 segments, got 2\n"},
+		{"validate 01.2.3 1.2.3-rc.01 1.2.3-0a", "", exitFalse,
+			"01.2.3: segment \"01\" must not have leading zeros\n" +
+				"1.2.3-rc.01: pre-release identifier \"01\" must not have leading zeros\n" +
+				"1.2.3-0a: valid\n"},
+		{"validate 1.2.3-a~b 1.2.3+a~b 1.2.3-rc.1+build-5", "", exitFalse,
+			"1.2.3-a~b: pre-release identifier \"a~b\" must only contain [0-9A-Za-z-]\n" +
+				"1.2.3+a~b: metadata identifier \"a~b\" must only contain [0-9A-Za-z-]\n" +
+				"1.2.3-rc.1+build-5: valid\n"},
+		{"validate -json 1.2.3.4", "", exitFalse,
+			"[\n  {\n    \"version\": \"1.2.3.4\",\n    \"valid\": false,\n    \"error\": \"version must have 3 segments, got 4\"\n  }\n]\n"},
+
+		{"", "", exitError, ""},
+		{"frobnicate", "", exitError, ""},
+	}
+
+	for _, tc := range cases {
+		var stdout, stderr bytes.Buffer
+		code := run(strings.Fields(tc.args), strings.NewReader(tc.stdin), &stdout, &stderr)
+		if code != tc.code {
+			t.Fatalf("%q: expected exit status %d, got %d\nstderr: %s", tc.args, tc.code, code, stderr.String())
+		}
+		if actual := stdout.String(); actual != tc.stdout {
+			t.Fatalf("%q\nexpected: %q\nactual: %q", tc.args, tc.stdout, actual)
+		}
+	}
+}
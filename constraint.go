@@ -4,16 +4,20 @@
 package version
 
 import (
+	"database/sql/driver"
 	"fmt"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 )
 
 var (
-	constraintRegexp     *regexp.Regexp
-	constraintRegexpOnce sync.Once
+	constraintRegexp             *regexp.Regexp
+	constraintRegexpOnce         sync.Once
+	wildcardConstraintRegexp     *regexp.Regexp
+	wildcardConstraintRegexpOnce sync.Once
 )
 
 func getConstraintRegexp() *regexp.Regexp {
@@ -28,17 +32,78 @@ func getConstraintRegexp() *regexp.Regexp {
 	return constraintRegexp
 }
 
+func getWildcardConstraintRegexp() *regexp.Regexp {
+	wildcardConstraintRegexpOnce.Do(func() {
+		// Wildcards may only replace trailing segments, e.g. "1.2.*" or
+		// "1.x.x", but not "1.*.3".
+		wildcardConstraintRegexp = regexp.MustCompile(fmt.Sprintf(
+			`^\s*(%s)\s*v?((?:[0-9]+\.)*)[*xX](?:\.[*xX])*\s*$`,
+			`<=|>=|!=|~>|<|>|=|`,
+		))
+	})
+	return wildcardConstraintRegexp
+}
+
 // Constraint represents a single constraint for a version, such as
 // ">= 1.0".
 type Constraint struct {
 	f        constraintFunc
-	op       operator
+	op       Operator
 	check    *Version
 	original string
+
+	// wildcard is set for constraints such as "1.2.*", in which case check
+	// only holds the segments that were given.
+	wildcard bool
+
+	// prerelease is the policy applied to pre-release versions, and
+	// prereleaseChecks are the pre-release versions of the constraints
+	// parsed along with this one, used by PrereleaseSameSegments.
+	prerelease       PrereleasePolicy
+	prereleaseChecks []*Version
+}
+
+// PrereleasePolicy controls which pre-release versions can satisfy a
+// constraint.
+type PrereleasePolicy int
+
+const (
+	// PrereleaseStrict is the default policy. A pre-release version only
+	// satisfies a constraint whose version is a pre-release with the same
+	// segments, so ">= 1.2" does not match "1.3.0-beta.1" and "~> 2.1.0-a"
+	// only matches pre-releases of 2.1.0.
+	PrereleaseStrict PrereleasePolicy = iota
+
+	// PrereleaseInclude compares pre-release versions like any other
+	// version, so ">= 1.2" matches "1.3.0-beta.1" and "~> 2.1.0-a" matches
+	// everything from "2.1.0-a" up to "2.2.0".
+	PrereleaseInclude
+
+	// PrereleaseSameSegments only allows a pre-release version if one of
+	// the constraints parsed together has a pre-release version with the
+	// same segments. For example ">= 1.2.0-beta, < 1.3" matches
+	// "1.2.0-rc.1" but not "1.2.5-beta".
+	PrereleaseSameSegments
+)
+
+// ConstraintOption is a functional option for NewConstraint and
+// ParseConstraint.
+type ConstraintOption func(*constraintOptions)
+
+type constraintOptions struct {
+	prerelease PrereleasePolicy
+}
+
+// WithPrereleasePolicy is a functional option that sets the policy used
+// by the parsed constraints to check pre-release versions.
+func WithPrereleasePolicy(p PrereleasePolicy) ConstraintOption {
+	return func(o *constraintOptions) {
+		o.prerelease = p
+	}
 }
 
 func (c *Constraint) Equals(con *Constraint) bool {
-	return c.op == con.op && c.check.Equal(con.check)
+	return c.op == con.op && c.wildcard == con.wildcard && c.check.Equal(con.check)
 }
 
 // Constraints is a slice of constraints. We make a custom type so that
@@ -48,14 +113,37 @@ type Constraints []*Constraint
 type constraintFunc func(v, c *Version) bool
 
 type constraintOperation struct {
-	op operator
-	f  constraintFunc
+	op     Operator
+	symbol string
+	f      constraintFunc
+}
+
+var constraintOperations = []constraintOperation{
+	{op: OpEqual, symbol: "=", f: constraintEqual},
+	{op: OpNotEqual, symbol: "!=", f: constraintNotEqual},
+	{op: OpGreaterThan, symbol: ">", f: constraintGreaterThan},
+	{op: OpLessThan, symbol: "<", f: constraintLessThan},
+	{op: OpGreaterThanEqual, symbol: ">=", f: constraintGreaterThanEqual},
+	{op: OpLessThanEqual, symbol: "<=", f: constraintLessThanEqual},
+	{op: OpPessimistic, symbol: "~>", f: constraintPessimistic},
 }
 
 // NewConstraint will parse one or more constraints from the given
 // constraint string. The string must be a comma-separated list of
 // constraints.
-func NewConstraint(v string) (Constraints, error) {
+//
+// Trailing segments of a version may be replaced with a "*", "x" or "X"
+// wildcard, such as "1.2.*". Without an operator, or with "=" or "~>",
+// this allows the same versions as ">= 1.2.0, < 1.3.0". The other
+// operators compare against that whole range: "> 1.2.*" means
+// ">= 1.3.0", "<= 1.2.*" means "< 1.3.0" and "!= 1.2.*" excludes it.
+// With the default PrereleaseStrict policy, wildcards reject pre-releases,
+// except "!=", which like other "!=" constraints accepts them unless they
+// are 1.2.x versions.
+//
+// Optional behavior can be enabled with ConstraintOption values such as
+// WithPrereleasePolicy.
+func NewConstraint(v string, opts ...ConstraintOption) (Constraints, error) {
 	vs := strings.Split(v, ",")
 	result := make([]*Constraint, len(vs))
 	for i, single := range vs {
@@ -67,9 +155,51 @@ func NewConstraint(v string) (Constraints, error) {
 		result[i] = c
 	}
 
+	applyConstraintOptions(result, opts)
+
 	return Constraints(result), nil
 }
 
+// applyConstraintOptions sets the given options on constraints that were
+// parsed together.
+func applyConstraintOptions(cs Constraints, opts []ConstraintOption) {
+	options := &constraintOptions{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(options)
+		}
+	}
+
+	var prereleaseChecks []*Version
+	for _, c := range cs {
+		if c.Prerelease() {
+			prereleaseChecks = append(prereleaseChecks, c.check)
+		}
+	}
+
+	for _, c := range cs {
+		c.prerelease = options.prerelease
+		c.prereleaseChecks = prereleaseChecks
+	}
+}
+
+// NewConstraintFromOperator returns a single constraint applying the
+// given operator to a version, without formatting and parsing a string.
+// The String of the result is built from both, e.g. ">= 1.0.0".
+func NewConstraintFromOperator(op Operator, v *Version) (*Constraint, error) {
+	if v == nil {
+		return nil, fmt.Errorf("constraint version must not be nil")
+	}
+
+	for _, o := range constraintOperations {
+		if o.op == op {
+			return newConstraint(op, v), nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown constraint operator: %s", op)
+}
+
 // MustConstraints is a helper that wraps a call to a function
 // returning (Constraints, error) and panics if error is non-nil.
 func MustConstraints(c Constraints, err error) Constraints {
@@ -134,7 +264,12 @@ func (cs Constraints) Less(i, j int) bool {
 		return false
 	}
 
-	return cs[i].check.LessThan(cs[j].check)
+	if cmp := cs[i].check.Compare(cs[j].check); cmp != 0 {
+		return cmp < 0
+	}
+
+	// "= 1.2.*" and "= 1.2.0" are different constraints on the same version
+	return cs[i].wildcard && !cs[j].wildcard
 }
 
 func (cs Constraints) Swap(i, j int) {
@@ -151,9 +286,114 @@ func (cs Constraints) String() string {
 	return strings.Join(csStr, ",")
 }
 
+// Canonical returns a normalized string format of the constraints that can
+// be used to compare or index them, e.g. "< 2.0.0, >= 1.0.0" for
+// " >=1.0,<2". Each constraint is formatted with Constraint.Canonical and
+// they are sorted the same way as sort.Sort(cs) would, with ties broken by
+// their canonical format so that the result does not depend on the order
+// of cs.
+func (cs Constraints) Canonical() string {
+	sorted := make(Constraints, len(cs))
+	copy(sorted, cs)
+
+	csStr := make([]string, len(sorted))
+	for i, c := range sorted {
+		csStr[i] = c.Canonical()
+	}
+	sort.Sort(canonicalConstraints{sorted, csStr})
+
+	return strings.Join(csStr, ", ")
+}
+
+// canonicalConstraints sorts constraints along with their canonical format,
+// which breaks the ties left by Constraints.Less.
+type canonicalConstraints struct {
+	cs   Constraints
+	strs []string
+}
+
+func (c canonicalConstraints) Len() int {
+	return len(c.cs)
+}
+
+func (c canonicalConstraints) Less(i, j int) bool {
+	if c.cs.Less(i, j) {
+		return true
+	}
+	if c.cs.Less(j, i) {
+		return false
+	}
+
+	return c.strs[i] < c.strs[j]
+}
+
+func (c canonicalConstraints) Swap(i, j int) {
+	c.cs.Swap(i, j)
+	c.strs[i], c.strs[j] = c.strs[j], c.strs[i]
+}
+
 // Check tests if a constraint is validated by the given version.
 func (c *Constraint) Check(v *Version) bool {
-	return c.f(v, c.check)
+	switch c.prerelease {
+	case PrereleaseInclude:
+	case PrereleaseSameSegments:
+		if v.Prerelease() != "" && !c.allowsPrerelease(v) {
+			return false
+		}
+	default:
+		if !c.wildcard {
+			return c.f(v, c.check)
+		}
+
+		// Like "!=" without a wildcard, "!= 1.*" accepts pre-releases,
+		// except those of the versions matched by the wildcard.
+		if c.op == OpNotEqual {
+			return !c.matchesWildcard(v)
+		}
+
+		// A wildcard never has a pre-release, so no pre-release version
+		// can satisfy it otherwise.
+		if v.Prerelease() != "" {
+			return false
+		}
+	}
+
+	for _, r := range c.ranges() {
+		if r.Contains(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesWildcard tests if v has the segments given before the wildcard,
+// e.g. if v is 1.2.x for "1.2.*", regardless of its pre-release. Missing
+// segments of v are 0, so 1.2.3 matches "1.2.3.0.*".
+func (c *Constraint) matchesWildcard(v *Version) bool {
+	for i := 0; i < c.check.si; i++ {
+		var s int64
+		if i < len(v.segments) {
+			s = v.segments[i]
+		}
+		if s != c.check.segments[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// allowsPrerelease tests if the pre-release version has the same segments
+// as one of the pre-release versions parsed along with the constraint.
+func (c *Constraint) allowsPrerelease(v *Version) bool {
+	for _, check := range c.prereleaseChecks {
+		if v.equalSegments(check) {
+			return true
+		}
+	}
+
+	return false
 }
 
 // Prerelease returns true if the version underlying this constraint
@@ -162,13 +402,49 @@ func (c *Constraint) Prerelease() bool {
 	return len(c.check.Prerelease()) > 0
 }
 
+// Operator returns the operator of the constraint. A constraint parsed
+// without an operator returns OpEqual.
+func (c *Constraint) Operator() Operator {
+	return c.op
+}
+
+// Version returns the version the constraint checks against, e.g. 1.0
+// for ">= 1.0". For a wildcard constraint, this holds the segments given
+// before the wildcard, e.g. 1.2 for "1.2.*".
+func (c *Constraint) Version() *Version {
+	return c.check
+}
+
+// Wildcard returns true if the version of the constraint ends with a
+// wildcard, such as "1.2.*".
+func (c *Constraint) Wildcard() bool {
+	return c.wildcard
+}
+
 func (c *Constraint) String() string {
 	return c.original
 }
 
+// Canonical returns a normalized string format of the constraint, made of
+// the operator, a single space and the canonical check version, e.g.
+// "= 1.0.0" for "1.0 ".
+//
+// The check version of "~>" keeps the number of segments it was given,
+// since "~> 1.2" and "~> 1.2.0" allow different versions.
+func (c *Constraint) Canonical() string {
+	if c.wildcard {
+		return c.op.String() + " " + wildcardString(c.check)
+	}
+
+	return c.op.String() + " " + checkString(c.op, c.check)
+}
+
 func parseSingle(v string) (*Constraint, error) {
 	matches := getConstraintRegexp().FindStringSubmatch(v)
 	if matches == nil {
+		if matches := getWildcardConstraintRegexp().FindStringSubmatch(v); matches != nil {
+			return parseWildcard(v, matches)
+		}
 		return nil, fmt.Errorf("malformed constraint: %s", v)
 	}
 
@@ -177,24 +453,12 @@ func parseSingle(v string) (*Constraint, error) {
 		return nil, err
 	}
 
-	var cop constraintOperation
-	switch matches[1] {
-	case "=":
-		cop = constraintOperation{op: equal, f: constraintEqual}
-	case "!=":
-		cop = constraintOperation{op: notEqual, f: constraintNotEqual}
-	case ">":
-		cop = constraintOperation{op: greaterThan, f: constraintGreaterThan}
-	case "<":
-		cop = constraintOperation{op: lessThan, f: constraintLessThan}
-	case ">=":
-		cop = constraintOperation{op: greaterThanEqual, f: constraintGreaterThanEqual}
-	case "<=":
-		cop = constraintOperation{op: lessThanEqual, f: constraintLessThanEqual}
-	case "~>":
-		cop = constraintOperation{op: pessimistic, f: constraintPessimistic}
-	default:
-		cop = constraintOperation{op: equal, f: constraintEqual}
+	// A missing operator is treated as "="
+	cop := constraintOperations[0]
+	for _, o := range constraintOperations {
+		if o.symbol == matches[1] {
+			cop = o
+		}
 	}
 
 	return &Constraint{
@@ -205,6 +469,83 @@ func parseSingle(v string) (*Constraint, error) {
 	}, nil
 }
 
+// parseWildcard returns the constraint for a single wildcard constraint
+// matched by the wildcard constraint regexp.
+func parseWildcard(v string, matches []string) (*Constraint, error) {
+	var segments []int64
+	if fixed := strings.TrimSuffix(matches[2], "."); fixed != "" {
+		for _, str := range strings.Split(fixed, ".") {
+			val, err := strconv.ParseInt(str, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"error parsing version: %s", err)
+			}
+			segments = append(segments, val)
+		}
+	}
+
+	cop := constraintOperations[0]
+	for _, o := range constraintOperations {
+		if o.symbol == matches[1] {
+			cop = o
+		}
+	}
+
+	return &Constraint{
+		op:       cop.op,
+		check:    newVersionFromSegments(segments),
+		original: v,
+		wildcard: true,
+	}, nil
+}
+
+// newConstraint returns a constraint applying the given operator to the
+// check version, as if it had been parsed from "<op> <version>".
+func newConstraint(op Operator, check *Version) *Constraint {
+	cop := constraintOperations[0]
+	for _, o := range constraintOperations {
+		if o.op == op {
+			cop = o
+		}
+	}
+
+	c := &Constraint{
+		f:     cop.f,
+		op:    cop.op,
+		check: check,
+	}
+	c.original = c.Canonical()
+
+	return c
+}
+
+// checkString returns the canonical string of a constraint's check
+// version. The pessimistic operator depends on how many segments were
+// given, so those are not padded with zeros like Version.String does.
+func checkString(op Operator, check *Version) string {
+	if op != OpPessimistic || check.si >= len(check.segments) {
+		return check.String()
+	}
+
+	specified := &Version{
+		segments: check.segments[:check.si],
+		pre:      check.pre,
+		metadata: check.metadata,
+	}
+	return specified.String()
+}
+
+// wildcardString returns the canonical string of a wildcard constraint's
+// check version, e.g. "1.2.*".
+func wildcardString(check *Version) string {
+	if check.si == 0 {
+		return "*"
+	}
+
+	specified := &Version{segments: check.segments[:check.si]}
+	return specified.String() + ".*"
+}
+
 func prereleaseCheck(v, c *Version) bool {
 	switch vPre, cPre := v.Prerelease() != "", c.Prerelease() != ""; {
 	case cPre && vPre:
@@ -225,22 +566,122 @@ func prereleaseCheck(v, c *Version) bool {
 	return true
 }
 
+// UnmarshalText implements encoding.TextUnmarshaler interface. An empty
+// text is decoded as empty Constraints, which MarshalText encodes it as.
+func (cs *Constraints) UnmarshalText(b []byte) error {
+	if strings.TrimSpace(string(b)) == "" {
+		*cs = nil
+		return nil
+	}
+
+	temp, err := NewConstraint(string(b))
+	if err != nil {
+		return err
+	}
+
+	*cs = temp
+
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+func (cs Constraints) MarshalText() ([]byte, error) {
+	return []byte(cs.String()), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (cs *Constraints) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case string:
+		return cs.UnmarshalText([]byte(src))
+	case []byte:
+		return cs.UnmarshalText(src)
+	case nil:
+		*cs = nil
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T as Constraints", src)
+	}
+}
+
+// Value implements the driver.Valuer interface. Empty Constraints are
+// stored as NULL.
+func (cs Constraints) Value() (driver.Value, error) {
+	if len(cs) == 0 {
+		return nil, nil
+	}
+
+	return cs.String(), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+func (c *Constraint) UnmarshalText(b []byte) error {
+	temp, err := parseSingle(string(b))
+	if err != nil {
+		return err
+	}
+
+	*c = *temp
+
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+func (c *Constraint) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (c *Constraint) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case string:
+		return c.UnmarshalText([]byte(src))
+	case []byte:
+		return c.UnmarshalText(src)
+	case nil:
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T as Constraint", src)
+	}
+}
+
+// Value implements the driver.Valuer interface.
+func (c *Constraint) Value() (driver.Value, error) {
+	return c.String(), nil
+}
+
 //-------------------------------------------------------------------
 // Constraint functions
 //-------------------------------------------------------------------
 
-type operator rune
+// Operator is the comparison operator of a Constraint, such as ">=" or
+// "~>".
+type Operator rune
 
+// The operators supported in constraints. A constraint without an
+// operator uses OpEqual.
 const (
-	equal            operator = '='
-	notEqual         operator = '≠'
-	greaterThan      operator = '>'
-	lessThan         operator = '<'
-	greaterThanEqual operator = '≥'
-	lessThanEqual    operator = '≤'
-	pessimistic      operator = '~'
+	OpEqual            Operator = '='
+	OpNotEqual         Operator = '≠'
+	OpGreaterThan      Operator = '>'
+	OpLessThan         Operator = '<'
+	OpGreaterThanEqual Operator = '≥'
+	OpLessThanEqual    Operator = '≤'
+	OpPessimistic      Operator = '~'
 )
 
+// String returns the operator as it is written in a constraint, such as
+// ">=" for OpGreaterThanEqual.
+func (op Operator) String() string {
+	for _, o := range constraintOperations {
+		if o.op == op {
+			return o.symbol
+		}
+	}
+
+	return fmt.Sprintf("Operator(%q)", rune(op))
+}
+
 func constraintEqual(v, c *Version) bool {
 	return v.Equal(c)
 }
@@ -0,0 +1,164 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package version
+
+// Matcher is a compiled form of Constraints, for checking many versions
+// against the same constraints. It is created with Constraints.Compile,
+// and gives the same results as Constraints.Check.
+type Matcher struct {
+	ranges []Range
+
+	// exclusions are the "!=" wildcard constraints with the strict
+	// pre-release policy, which are checked by their segments rather than
+	// their ranges, as "!= 1.*" accepts 2.0.0-beta.
+	exclusions []*Constraint
+
+	// releases is false if no version without a pre-release can match,
+	// as for "~> 2.1.0-a".
+	releases bool
+
+	// minSegments is the least number of segments a version must have, as
+	// the pessimistic operator rejects versions with less segments than
+	// its own.
+	minSegments int
+
+	// prereleases is true if any pre-release version within the ranges can
+	// match. Otherwise only pre-releases with the same segments as one of
+	// prereleaseSegments can.
+	prereleases        bool
+	prereleaseSegments []*Version
+}
+
+// Compile returns a Matcher for the constraints. The bounds of all the
+// constraints and their pre-release rules are merged once, so that
+// checking a version with the Matcher does not allocate and takes fewer
+// comparisons than Check.
+func (cs Constraints) Compile() *Matcher {
+	var ranged Constraints
+	var exclusions []*Constraint
+	for _, c := range cs {
+		if c.prerelease == PrereleaseStrict && c.wildcard && c.op == OpNotEqual {
+			exclusions = append(exclusions, c)
+		} else {
+			ranged = append(ranged, c)
+		}
+	}
+
+	m := &Matcher{
+		ranges:      ranged.Ranges(),
+		exclusions:  exclusions,
+		releases:    true,
+		prereleases: true,
+	}
+
+	for _, c := range ranged {
+		var allowed []*Version
+		switch c.prerelease {
+		case PrereleaseInclude:
+			continue
+		case PrereleaseSameSegments:
+			allowed = c.prereleaseChecks
+		default:
+			if c.wildcard {
+				break
+			}
+			if c.op == OpEqual || c.op == OpNotEqual {
+				continue
+			}
+			if c.Prerelease() {
+				allowed = []*Version{c.check}
+			}
+			if c.op == OpPessimistic {
+				m.releases = m.releases && !c.Prerelease()
+				if n := len(c.check.segments); n > m.minSegments {
+					m.minSegments = n
+				}
+			}
+		}
+
+		if m.prereleases {
+			m.prereleases = false
+			m.prereleaseSegments = allowed
+			continue
+		}
+		var kept []*Version
+		for _, p := range m.prereleaseSegments {
+			for _, a := range allowed {
+				if p.equalSegments(a) {
+					kept = append(kept, p)
+					break
+				}
+			}
+		}
+		m.prereleaseSegments = kept
+	}
+
+	return m
+}
+
+// Check tests if a version satisfies the compiled constraints.
+func (m *Matcher) Check(v *Version) bool {
+	if len(v.segments) < m.minSegments {
+		return false
+	}
+	for _, c := range m.exclusions {
+		if c.matchesWildcard(v) {
+			return false
+		}
+	}
+
+	if v.pre == "" {
+		if !m.releases {
+			return false
+		}
+	} else if !m.prereleases && !m.allowsPrerelease(v) {
+		return false
+	}
+
+	// Find the first range that does not end before v
+	lo, hi := 0, len(m.ranges)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if m.ranges[mid].endsBefore(v) {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+
+	return lo < len(m.ranges) && m.ranges[lo].Contains(v)
+}
+
+// Filter returns the versions that satisfy the compiled constraints, in
+// the order they were given.
+func (m *Matcher) Filter(versions Collection) Collection {
+	result := make(Collection, 0, len(versions))
+	for _, v := range versions {
+		if m.Check(v) {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+func (m *Matcher) allowsPrerelease(v *Version) bool {
+	for _, p := range m.prereleaseSegments {
+		if v.equalSegments(p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// endsBefore tests if every version in the range is less than v.
+func (r Range) endsBefore(v *Version) bool {
+	if r.Upper.Version == nil {
+		return false
+	}
+
+	cmp := r.Upper.Version.Compare(v)
+	return cmp < 0 || (cmp == 0 && !r.Upper.Inclusive)
+}
@@ -0,0 +1,153 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package version
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMatcherCheck(t *testing.T) {
+	constraints := []string{
+		">= 1.0, < 1.2",
+		"< 1.0, < 1.2",
+		"= 1.0",
+		"!= 1.0",
+		"~> 1.0",
+		"~> 1.0.7",
+		"~> 1.0.9.5",
+		"~> 1.0.9.0",
+		"~> 2.1.0-a",
+		"> 2.0",
+		">= 2.1.0-a",
+		"<= 2.1.0-a",
+		"= 2.1.0-beta",
+		">= 2.1.0-a, < 2.2",
+		">= 2.1.0-a, < 2.1.0-c",
+		">= 1.0, < 3.0, != 2.0, != 2.1.0-beta",
+		"1.2.*",
+		"!= 1.*",
+		"!= 1.2.*, >= 1.0",
+		"!= 2.*, ~> 2.1.0-a",
+		"!= 1.0.9.5.*",
+		"!= 1.0.0.0.*",
+		"*",
+	}
+	versions := []string{
+		"0.9", "0.9.0-beta", "1.0", "1.0.0-beta", "1.0.4", "1.0.7", "1.0.7.5", "1.0.9",
+		"1.0.9.4", "1.0.9.5", "1.0.9.5.1", "1.0.10", "1.1", "1.1.5", "1.2",
+		"1.2.3", "1.2.3-rc1", "2.0", "2.0.0-alpha", "2.1.0", "2.1.0-a",
+		"2.1.0-beta", "2.1.0-c", "2.1.1", "2.1.1-beta", "2.2.0-alpha", "2.2.0",
+		"3.0",
+	}
+	policies := []PrereleasePolicy{
+		PrereleaseStrict,
+		PrereleaseInclude,
+		PrereleaseSameSegments,
+	}
+
+	for _, policy := range policies {
+		for _, cStr := range constraints {
+			c := MustConstraints(NewConstraint(cStr, WithPrereleasePolicy(policy)))
+			m := c.Compile()
+			for _, vStr := range versions {
+				v := Must(NewVersion(vStr))
+
+				if expected, actual := c.Check(v), m.Check(v); expected != actual {
+					t.Fatalf("Version: %s\nConstraint: %s\nPolicy: %d\nExpected: %#v",
+						vStr, cStr, policy, expected)
+				}
+			}
+		}
+	}
+}
+
+func TestMatcherCheck_mixedPolicies(t *testing.T) {
+	strict := MustConstraints(NewConstraint(">= 1.0.0-beta"))
+	include := MustConstraints(NewConstraint("< 2.0", WithPrereleasePolicy(PrereleaseInclude)))
+	c := append(strict, include...)
+	m := c.Compile()
+
+	for _, vStr := range []string{"1.0.0-beta.2", "1.0.0-alpha", "1.5.0-beta", "1.5.0", "2.0.0"} {
+		v := Must(NewVersion(vStr))
+		if expected, actual := c.Check(v), m.Check(v); expected != actual {
+			t.Fatalf("Version: %s\nConstraint: %s\nExpected: %#v", vStr, c, expected)
+		}
+	}
+}
+
+func TestMatcherFilter(t *testing.T) {
+	m := MustConstraints(NewConstraint("~> 1.2, != 1.4.0")).Compile()
+
+	versions := make(Collection, 0)
+	for _, raw := range []string{"2.0", "1.5", "1.1", "1.4.0", "1.2.3", "1.3-beta"} {
+		versions = append(versions, Must(NewVersion(raw)))
+	}
+
+	actual := fmt.Sprint(m.Filter(versions))
+	expected := "[1.5.0 1.2.3]"
+	if actual != expected {
+		t.Fatalf("Expected: %s\nActual: %s", expected, actual)
+	}
+}
+
+func TestMatcherCheck_allocs(t *testing.T) {
+	m := MustConstraints(NewConstraint(">= 1.0.0-beta, < 3.0, != 2.0.0, ~> 1.2")).Compile()
+	versions := []*Version{
+		Must(NewVersion("1.5.0")),
+		Must(NewVersion("1.0.0-rc.1")),
+		Must(NewVersion("1.3.0-beta.2")),
+		Must(NewVersion("2.0.0")),
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		for _, v := range versions {
+			m.Check(v)
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("expected no allocations, got %v", allocs)
+	}
+}
+
+func benchmarkVersions() Collection {
+	versions := make(Collection, 0, 1000)
+	for major := 0; major < 10; major++ {
+		for minor := 0; minor < 10; minor++ {
+			for patch := 0; patch < 10; patch++ {
+				raw := fmt.Sprintf("%d.%d.%d", major, minor, patch)
+				if patch%3 == 0 {
+					raw += "-beta.1"
+				}
+				versions = append(versions, Must(NewVersion(raw)))
+			}
+		}
+	}
+
+	return versions
+}
+
+func BenchmarkConstraintsCheck(b *testing.B) {
+	c := MustConstraints(NewConstraint(">= 1.2, < 8.0, != 4.5.6, != 5.0.0, ~> 3.0"))
+	versions := benchmarkVersions()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, v := range versions {
+			c.Check(v)
+		}
+	}
+}
+
+func BenchmarkMatcherFilter(b *testing.B) {
+	m := MustConstraints(NewConstraint(">= 1.2, < 8.0, != 4.5.6, != 5.0.0, ~> 3.0")).Compile()
+	versions := benchmarkVersions()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Filter(versions)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package version
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConstraintError describes a malformed part of a constraint string.
+// Start and End are the byte offsets of that part in the parsed string,
+// so that s[Start:End] is the text to point at.
+type ConstraintError struct {
+	Start   int
+	End     int
+	Message string
+}
+
+func (e *ConstraintError) Error() string {
+	return fmt.Sprintf("%s at bytes %d-%d", e.Message, e.Start, e.End)
+}
+
+// ConstraintErrors is the list of errors returned by ParseConstraint,
+// sorted by position.
+type ConstraintErrors []*ConstraintError
+
+func (e ConstraintErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// ParseConstraint parses one or more constraints like NewConstraint does,
+// but it does not stop at the first malformed constraint. If any are
+// found, the returned error is a ConstraintErrors listing all of them
+// along with their position in v.
+func ParseConstraint(v string, opts ...ConstraintOption) (Constraints, error) {
+	var result Constraints
+	var errs ConstraintErrors
+
+	start := 0
+	for _, single := range strings.Split(v, ",") {
+		end := start + len(single)
+
+		c, err := parseSingle(single)
+		if err != nil {
+			errs = append(errs, diagnoseSingle(v, start, end, err))
+		} else {
+			result = append(result, c)
+		}
+
+		// Skip the comma
+		start = end + 1
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	applyConstraintOptions(result, opts)
+
+	return result, nil
+}
+
+// diagnoseSingle returns an error pointing at the part of v[start:end]
+// that made it fail to parse with the given error.
+func diagnoseSingle(v string, start, end int, err error) *ConstraintError {
+	single := v[start:end]
+	tokenStart := start + len(single) - len(strings.TrimLeft(single, " \t\n\r"))
+	tokenEnd := start + len(strings.TrimRight(single, " \t\n\r"))
+	if tokenStart >= tokenEnd {
+		return &ConstraintError{Start: start, End: end, Message: "empty constraint"}
+	}
+
+	opEnd := tokenStart
+	for opEnd < tokenEnd && strings.ContainsRune("<>=!~", rune(v[opEnd])) {
+		opEnd++
+	}
+	if op := v[tokenStart:opEnd]; op != "" && !isOperatorSymbol(op) {
+		return &ConstraintError{
+			Start:   tokenStart,
+			End:     opEnd,
+			Message: fmt.Sprintf("unknown operator %q", op),
+		}
+	}
+
+	versionStart := opEnd
+	for versionStart < tokenEnd && strings.ContainsRune(" \t\n\r", rune(v[versionStart])) {
+		versionStart++
+	}
+	if versionStart == tokenEnd {
+		return &ConstraintError{
+			Start:   tokenStart,
+			End:     tokenEnd,
+			Message: fmt.Sprintf("missing version after %q", v[tokenStart:opEnd]),
+		}
+	}
+
+	if _, verr := NewVersion(v[versionStart:tokenEnd]); verr != nil {
+		err = verr
+	}
+
+	return &ConstraintError{
+		Start:   versionStart,
+		End:     tokenEnd,
+		Message: err.Error(),
+	}
+}
+
+func isOperatorSymbol(s string) bool {
+	for _, o := range constraintOperations {
+		if o.symbol == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,99 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package version
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseConstraint(t *testing.T) {
+	cases := []struct {
+		input  string
+		count  int
+		errors []ConstraintError
+	}{
+		{">= 1.2, < 2.0", 2, nil},
+		{"1.2.*", 1, nil},
+		{
+			">= 1.2, => 2.0",
+			0,
+			[]ConstraintError{{8, 10, `unknown operator "=>"`}},
+		},
+		{
+			">= 1.2,, < 2.0",
+			0,
+			[]ConstraintError{{7, 7, "empty constraint"}},
+		},
+		{
+			">= 1.2, <  ",
+			0,
+			[]ConstraintError{{8, 9, `missing version after "<"`}},
+		},
+		{
+			">= foo, ~> 1.2.beta, 1.*.3",
+			0,
+			[]ConstraintError{
+				{3, 6, "malformed version: foo"},
+				{11, 19, "malformed version: 1.2.beta"},
+				{21, 26, "malformed version: 1.*.3"},
+			},
+		},
+		{
+			"< 1.0, >= 11387778780781445675529500000000000000000",
+			0,
+			[]ConstraintError{{
+				10, 51,
+				`error parsing version: strconv.ParseInt: parsing "11387778780781445675529500000000000000000": value out of range`,
+			}},
+		},
+	}
+
+	for _, tc := range cases {
+		c, err := ParseConstraint(tc.input)
+		if len(c) != tc.count {
+			t.Fatalf("input: %s\nexpected len: %d\nactual: %d",
+				tc.input, tc.count, len(c))
+		}
+
+		if tc.errors == nil {
+			if err != nil {
+				t.Fatalf("error for input %s: %s", tc.input, err)
+			}
+			continue
+		}
+
+		errs, ok := err.(ConstraintErrors)
+		if !ok {
+			t.Fatalf("input: %s\nexpected ConstraintErrors, got: %#v", tc.input, err)
+		}
+
+		actual := make([]ConstraintError, len(errs))
+		for i, e := range errs {
+			actual[i] = *e
+		}
+		if !reflect.DeepEqual(actual, tc.errors) {
+			t.Fatalf("input: %s\nexpected: %#v\nactual: %#v", tc.input, tc.errors, actual)
+		}
+	}
+}
+
+func TestParseConstraint_options(t *testing.T) {
+	c, err := ParseConstraint(">= 1.2", WithPrereleasePolicy(PrereleaseInclude))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if !c.Check(Must(NewVersion("1.3.0-beta.1"))) {
+		t.Fatalf("expected %s to include pre-releases", c)
+	}
+}
+
+func TestConstraintErrorsError(t *testing.T) {
+	_, err := ParseConstraint("=> 1.0,")
+	expected := `unknown operator "=>" at bytes 0-2; empty constraint at bytes 7-7`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected: %s\nactual: %v", expected, err)
+	}
+}
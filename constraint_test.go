@@ -4,9 +4,11 @@
 package version
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"sort"
+	"strings"
 	"testing"
 )
 
@@ -18,8 +20,15 @@ func TestNewConstraint(t *testing.T) {
 	}{
 		{">= 1.2", 1, false},
 		{"1.0", 1, false},
-		{">= 1.x", 0, true},
+		{">= 1.x", 1, false},
+		{"1.2.*", 1, false},
+		{"= 1.X.x", 1, false},
+		{"*", 1, false},
 		{">= 1.2, < 1.0", 2, false},
+		{"1.*.3", 0, true},
+		{"1.2.*-beta", 0, true},
+		{"1.2.**", 0, true},
+		{"1.2*", 0, true},
 
 		// Out of bounds
 		{"11387778780781445675529500000000000000000", 0, true},
@@ -167,6 +176,16 @@ func TestConstraintEqual(t *testing.T) {
 			"<=1.0.0, >0.1.0",
 			true,
 		},
+		{ // different order with a wildcard
+			"= 1.2.*, = 1.2.0",
+			"= 1.2.0, = 1.2.*",
+			true,
+		},
+		{ // wildcard difference
+			"= 1.2.*, = 1.2.*",
+			"= 1.2.0, = 1.2.*",
+			false,
+		},
 	}
 
 	for _, tc := range cases {
@@ -256,3 +275,452 @@ func TestConstraintsString(t *testing.T) {
 		}
 	}
 }
+
+func TestConstraintOperator(t *testing.T) {
+	cases := []struct {
+		constraint string
+		op         Operator
+		symbol     string
+		version    string
+	}{
+		{"= 1.0", OpEqual, "=", "1.0.0"},
+		{"1.0", OpEqual, "=", "1.0.0"},
+		{"!= 1.0", OpNotEqual, "!=", "1.0.0"},
+		{"> 1.0", OpGreaterThan, ">", "1.0.0"},
+		{"< 1.0", OpLessThan, "<", "1.0.0"},
+		{">= 1.0-beta", OpGreaterThanEqual, ">=", "1.0.0-beta"},
+		{"<= 1.0", OpLessThanEqual, "<=", "1.0.0"},
+		{"~> 1.0", OpPessimistic, "~>", "1.0.0"},
+	}
+
+	for _, tc := range cases {
+		c, err := parseSingle(tc.constraint)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		if c.Operator() != tc.op {
+			t.Fatalf("Constraint: %s\nExpected operator: %s\nActual: %s",
+				tc.constraint, tc.op, c.Operator())
+		}
+		if c.Operator().String() != tc.symbol {
+			t.Fatalf("Constraint: %s\nExpected symbol: %s\nActual: %s",
+				tc.constraint, tc.symbol, c.Operator())
+		}
+		if c.Version().String() != tc.version {
+			t.Fatalf("Constraint: %s\nExpected version: %s\nActual: %s",
+				tc.constraint, tc.version, c.Version())
+		}
+	}
+}
+
+func TestNewConstraintFromOperator(t *testing.T) {
+	cases := []struct {
+		op       Operator
+		version  string
+		expected string
+		err      bool
+	}{
+		{OpGreaterThanEqual, "1.0", ">= 1.0.0", false},
+		{OpPessimistic, "v1.2.3-beta", "~> 1.2.3-beta", false},
+		{OpPessimistic, "1.2", "~> 1.2", false},
+		{OpNotEqual, "1.0", "!= 1.0.0", false},
+		{Operator('?'), "1.0", "", true},
+		{OpEqual, "", "", true},
+	}
+
+	for _, tc := range cases {
+		var v *Version
+		if tc.version != "" {
+			v = Must(NewVersion(tc.version))
+		}
+
+		c, err := NewConstraintFromOperator(tc.op, v)
+		if tc.err && err == nil {
+			t.Fatalf("expected error for operator %s and version %q", tc.op, tc.version)
+		} else if !tc.err && err != nil {
+			t.Fatalf("error for operator %s and version %q: %s", tc.op, tc.version, err)
+		}
+		if tc.err {
+			continue
+		}
+
+		if c.String() != tc.expected {
+			t.Fatalf("Expected: %s\nActual: %s", tc.expected, c)
+		}
+
+		// The constraint should behave as if the string was parsed
+		parsed := MustConstraints(NewConstraint(tc.expected))
+		if !(Constraints{c}).Equals(parsed) {
+			t.Fatalf("Constraint %s differs from parsed %s", c, parsed)
+		}
+		if rangesString(Constraints{c}.Ranges()) != rangesString(parsed.Ranges()) {
+			t.Fatalf("Constraint %s allows different versions than parsed %s", c, parsed)
+		}
+	}
+}
+
+func TestConstraintsCanonical(t *testing.T) {
+	cases := []struct {
+		constraint string
+		expected   string
+	}{
+		{" >=1.0 ,<2", "< 2.0.0, >= 1.0.0"},
+		{">= 1.0.0, < 2.0.0", "< 2.0.0, >= 1.0.0"},
+		{"1.0", "= 1.0.0"},
+		{"=v1.0.0 ", "= 1.0.0"},
+		{"~>1.2", "~> 1.2"},
+		{"~> 1.2.0", "~> 1.2.0"},
+		{"~> 1.2-beta", "~> 1.2-beta"},
+		{"!=1.5,!=1.4, >1.0", "> 1.0.0, != 1.4.0, != 1.5.0"},
+		{">1.0,>0.1.0,>0.3.0,>0.2.0", "> 0.1.0, > 0.2.0, > 0.3.0, > 1.0.0"},
+		{"<= 1.0.0+meta", "<= 1.0.0+meta"},
+		{"1.2.*, 1.2.0", "= 1.2.*, = 1.2.0"},
+		{"~> 1.2, ~> 1.2.0", "~> 1.2, ~> 1.2.0"},
+		{">= 1.0+a, >= 1.0+b", ">= 1.0.0+a, >= 1.0.0+b"},
+	}
+
+	for _, tc := range cases {
+		c, err := NewConstraint(tc.constraint)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		original := c.String()
+		actual := c.Canonical()
+		if actual != tc.expected {
+			t.Fatalf("Constraint: %s\nExpected: %q\nActual: %q",
+				tc.constraint, tc.expected, actual)
+		}
+
+		// Canonical must not reorder the constraints themselves
+		if c.String() != original {
+			t.Fatalf("Constraint: %s\nwas modified to %s", tc.constraint, c)
+		}
+
+		// The canonical form must be stable
+		again := MustConstraints(NewConstraint(actual)).Canonical()
+		if again != actual {
+			t.Fatalf("Constraint: %s\nExpected: %q\nActual: %q",
+				actual, actual, again)
+		}
+
+		// and must not depend on the order of the constraints
+		parts := strings.Split(tc.constraint, ",")
+		for i, j := 0, len(parts)-1; i < j; i, j = i+1, j-1 {
+			parts[i], parts[j] = parts[j], parts[i]
+		}
+		reversed := strings.Join(parts, ",")
+		if again := MustConstraints(NewConstraint(reversed)).Canonical(); again != actual {
+			t.Fatalf("Constraint: %s\nExpected: %q\nActual: %q",
+				reversed, actual, again)
+		}
+	}
+}
+
+func TestConstraintsJsonMarshal(t *testing.T) {
+	type config struct {
+		Required   Constraints `json:"required"`
+		Constraint *Constraint `json:"constraint"`
+	}
+
+	cases := []struct {
+		json string
+		err  bool
+	}{
+		{`{"required":"\u003e= 1.0, \u003c 2.0","constraint":"~\u003e 1.2"}`, false},
+		{`{"required":"1.0","constraint":"1.0"}`, false},
+		{`{"required":"\u003e= 1.x.2"}`, true},
+		{`{"constraint":"\u003e= 1.0, \u003c 2.0"}`, true},
+		{`{"required":1}`, true},
+	}
+
+	for _, tc := range cases {
+		var c config
+		err := json.Unmarshal([]byte(tc.json), &c)
+		if tc.err && err == nil {
+			t.Fatalf("expected error for json: %s", tc.json)
+		} else if !tc.err && err != nil {
+			t.Fatalf("error for json %s: %s", tc.json, err)
+		}
+		if tc.err {
+			continue
+		}
+
+		actual, err := json.Marshal(c)
+		if err != nil {
+			t.Fatalf("error marshaling %s: %s", tc.json, err)
+		}
+		if string(actual) != tc.json {
+			t.Fatalf("Expected: %s\nActual: %s", tc.json, actual)
+		}
+	}
+}
+
+func TestConstraintsScan(t *testing.T) {
+	cases := []struct {
+		src      interface{}
+		expected interface{}
+		err      bool
+	}{
+		{">= 1.0, < 2.0", ">= 1.0, < 2.0", false},
+		{[]byte("~> 1.2"), "~> 1.2", false},
+		{nil, nil, false},
+		{"", nil, false},
+		{"foo", nil, true},
+		{1, nil, true},
+	}
+
+	for _, tc := range cases {
+		var cs Constraints
+		err := cs.Scan(tc.src)
+		if tc.err && err == nil {
+			t.Fatalf("expected error for source: %#v", tc.src)
+		} else if !tc.err && err != nil {
+			t.Fatalf("error for source %#v: %s", tc.src, err)
+		}
+		if tc.err {
+			continue
+		}
+
+		value, err := cs.Value()
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if value != tc.expected {
+			t.Fatalf("Source: %#v\nExpected: %q\nActual: %q", tc.src, tc.expected, value)
+		}
+	}
+}
+
+func TestConstraints_zeroValueRoundTrip(t *testing.T) {
+	type config struct {
+		Required Constraints `json:"required"`
+	}
+
+	b, err := json.Marshal(config{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if string(b) != `{"required":""}` {
+		t.Fatalf("unexpected json: %s", b)
+	}
+
+	c := config{Required: MustConstraints(NewConstraint(">= 1.0"))}
+	if err := json.Unmarshal(b, &c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(c.Required) != 0 {
+		t.Fatalf("expected empty constraints, got %s", c.Required)
+	}
+
+	var empty Constraints
+	value, err := empty.Value()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if value != nil {
+		t.Fatalf("expected nil value, got %#v", value)
+	}
+
+	cs := MustConstraints(NewConstraint(">= 1.0"))
+	if err := cs.Scan(value); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(cs) != 0 {
+		t.Fatalf("expected empty constraints, got %s", cs)
+	}
+}
+
+func TestConstraintScan(t *testing.T) {
+	var c Constraint
+	if err := c.Scan("~> 1.2"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if c.Operator() != OpPessimistic || !c.Check(Must(NewVersion("1.5"))) {
+		t.Fatalf("unexpected constraint: %s", &c)
+	}
+
+	value, err := c.Value()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if value != "~> 1.2" {
+		t.Fatalf("unexpected value: %#v", value)
+	}
+
+	if err := c.Scan(">= 1.0, < 2.0"); err == nil {
+		t.Fatalf("expected error scanning more than one constraint")
+	}
+}
+
+func TestConstraintCheckPrereleasePolicy(t *testing.T) {
+	cases := []struct {
+		policy     PrereleasePolicy
+		constraint string
+		version    string
+		check      bool
+	}{
+		{PrereleaseStrict, ">= 1.2", "1.3.0-beta.1", false},
+		{PrereleaseStrict, ">= 1.2.0-beta, < 1.3", "1.2.0-rc.1", false},
+		{PrereleaseStrict, "~> 2.1.0-a", "2.1.0", false},
+
+		{PrereleaseInclude, ">= 1.2", "1.3.0-beta.1", true},
+		{PrereleaseInclude, ">= 1.2", "1.2.0-beta.1", false},
+		{PrereleaseInclude, "> 1.2", "1.2.1-beta.1", true},
+		{PrereleaseInclude, "< 1.3", "1.3.0-beta.1", true},
+		{PrereleaseInclude, "<= 1.3", "1.3.1-beta.1", false},
+		{PrereleaseInclude, "~> 1.2", "1.9.0-beta.1", true},
+		{PrereleaseInclude, "~> 1.2", "2.0.0-beta.1", true},
+		{PrereleaseInclude, "~> 1.2", "2.0.0", false},
+		{PrereleaseInclude, "~> 2.1.0-a", "2.1.0", true},
+		{PrereleaseInclude, "~> 2.1.0-a", "2.1.5-beta", true},
+		{PrereleaseInclude, "~> 2.1.0-a", "2.2.0-alpha", true},
+		{PrereleaseInclude, "~> 2.1.0-a", "2.2.0", false},
+		{PrereleaseInclude, "!= 1.3.0-beta.1", "1.3.0-beta.1", false},
+		{PrereleaseInclude, "!= 1.3.0-beta.1", "1.3.0-beta.2", true},
+		{PrereleaseInclude, ">= 1.0, < 2.0", "1.5.0", true},
+
+		{PrereleaseSameSegments, ">= 1.2", "1.3.0-beta.1", false},
+		{PrereleaseSameSegments, ">= 1.2.0-beta, < 1.3", "1.2.0-rc.1", true},
+		{PrereleaseSameSegments, ">= 1.2.0-beta, < 1.3", "1.2.0-alpha", false},
+		{PrereleaseSameSegments, ">= 1.2.0-beta, < 1.3", "1.2.5-beta", false},
+		{PrereleaseSameSegments, ">= 1.2.0-beta, < 1.3", "1.2.5", true},
+		{PrereleaseSameSegments, "!= 1.0", "1.2.0-beta", false},
+		{PrereleaseSameSegments, "~> 2.1.0-a", "2.1.0-beta", true},
+		{PrereleaseSameSegments, "~> 2.1.0-a", "2.1.0", true},
+		{PrereleaseSameSegments, "~> 2.1.0-a", "2.1.1-beta", false},
+		{PrereleaseSameSegments, "~> 2.1.0-a", "2.2.0", false},
+	}
+
+	for _, tc := range cases {
+		c, err := NewConstraint(tc.constraint, WithPrereleasePolicy(tc.policy))
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		v, err := NewVersion(tc.version)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		actual := c.Check(v)
+		expected := tc.check
+		if actual != expected {
+			t.Fatalf("Version: %s\nConstraint: %s\nPolicy: %d\nExpected: %#v",
+				tc.version, tc.constraint, tc.policy, expected)
+		}
+	}
+}
+
+func TestConstraintCheckWildcard(t *testing.T) {
+	cases := []struct {
+		constraint string
+		version    string
+		check      bool
+	}{
+		{"1.2.*", "1.2.0", true},
+		{"1.2.*", "1.2.9", true},
+		{"1.2.*", "1.2.9.1", true},
+		{"1.2.*", "1.3.0", false},
+		{"1.2.*", "1.1.9", false},
+		{"1.2.*", "1.2.5-beta", false},
+		{"1.2.x", "1.2.5", true},
+		{"1.X", "1.9.0", true},
+		{"1.x.x", "2.0.0", false},
+		{"= 1.2.*", "1.2.5", true},
+		{"~> 1.2.*", "1.2.5", true},
+		{"~> 1.2.*", "1.3.0", false},
+		{"!= 1.2.*", "1.2.5", false},
+		{"!= 1.2.*", "1.1.0", true},
+		{"!= 1.2.*", "1.3.0", true},
+		{"!= 1.*", "2.0.0-beta", true},
+		{"!= 1.*", "0.9.0-beta", true},
+		{"!= 1.*", "1.5.0-beta", false},
+		{"!= 1.0", "2.0.0-beta", true},
+		{"!= 1.2.*", "1.3.0-rc.1", true},
+		{"!= 1.2.*", "1.2.0-rc.1", false},
+		{"!= *", "1.0.0-beta", false},
+		{"!= 1.2.3.4.*", "1.2.3", true},
+		{"!= 1.2.3.0.*", "1.2.3", false},
+		{"!= 1.2.3.0.*", "1.2.3-beta", false},
+		{"> 1.2.*", "1.2.9", false},
+		{"> 1.2.*", "1.3.0", true},
+		{">= 1.2.*", "1.2.0", true},
+		{">= 1.2.*", "1.1.9", false},
+		{"< 1.2.*", "1.1.9", true},
+		{"< 1.2.*", "1.2.0", false},
+		{"<= 1.2.*", "1.2.9", true},
+		{"<= 1.2.*", "1.3.0", false},
+		{"*", "0.0.1", true},
+		{"*", "100.0.0", true},
+		{"*", "1.0.0-beta", false},
+		{"!= *", "1.0.0", false},
+		{"> *", "1.0.0", false},
+		{"< *", "1.0.0", false},
+		{">= 1.0, < 2.0, != 1.2.*", "1.2.5", false},
+		{">= 1.0, < 2.0, != 1.2.*", "1.3.0", true},
+	}
+
+	for _, tc := range cases {
+		c, err := NewConstraint(tc.constraint)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		v, err := NewVersion(tc.version)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		actual := c.Check(v)
+		expected := tc.check
+		if actual != expected {
+			t.Fatalf("Version: %s\nConstraint: %s\nExpected: %#v",
+				tc.version, tc.constraint, expected)
+		}
+	}
+}
+
+func TestConstraintWildcard(t *testing.T) {
+	cases := []struct {
+		constraint string
+		canonical  string
+		ranges     string
+	}{
+		{"1.2.*", "= 1.2.*", ">= 1.2.0, < 1.3.0"},
+		{"v1.x", "= 1.*", ">= 1.0.0, < 2.0.0"},
+		{"~> 1.2.X", "~> 1.2.*", ">= 1.2.0, < 1.3.0"},
+		{"!= 1.2.*", "!= 1.2.*", "< 1.2.0 || >= 1.3.0"},
+		{"> 1.2.*", "> 1.2.*", ">= 1.3.0"},
+		{"<= 1.2.*", "<= 1.2.*", "< 1.3.0"},
+		{"1.2.3.*", "= 1.2.3.*", ">= 1.2.3, < 1.2.4"},
+		{"*", "= *", ">= 0.0.0"},
+		{"!= *", "!= *", ""},
+	}
+
+	for _, tc := range cases {
+		c, err := NewConstraint(tc.constraint)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		if !c[0].Wildcard() {
+			t.Fatalf("Constraint: %s\nexpected a wildcard", tc.constraint)
+		}
+		if actual := c.Canonical(); actual != tc.canonical {
+			t.Fatalf("Constraint: %s\nExpected canonical: %q\nActual: %q",
+				tc.constraint, tc.canonical, actual)
+		}
+		if actual := rangesString(c.Ranges()); actual != tc.ranges {
+			t.Fatalf("Constraint: %s\nExpected ranges: %q\nActual: %q",
+				tc.constraint, tc.ranges, actual)
+		}
+
+		// A wildcard must not be equal to the version it was made from
+		exact := MustConstraints(NewConstraint(c[0].Operator().String() + " 1.2"))
+		if c.Equals(exact) {
+			t.Fatalf("Constraint: %s\nequals %s", tc.constraint, exact)
+		}
+	}
+}
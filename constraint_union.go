@@ -0,0 +1,218 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package version
+
+import (
+	"strings"
+)
+
+// ConstraintsUnion is a list of Constraints of which at least one must be
+// satisfied, such as ">= 1.0, < 1.2 || >= 2.0".
+//
+// A ConstraintsUnion returned by this package is normalized: its groups
+// are sorted, they do not overlap, and each one is made of at most a lower
+// bound, an upper bound and any number of "!=" exclusions.
+//
+// Unions and complements are computed from Ranges, which ignore
+// pre-release rules. Their result uses PrereleaseInclude if all the
+// constraints they are computed from do, and PrereleaseStrict otherwise.
+// With PrereleaseStrict, a pre-release may satisfy neither some
+// constraints nor their complement: 1.5.0-beta satisfies neither ">= 1.0"
+// nor its complement "< 1.0.0". A version without a pre-release always
+// satisfies exactly one of them.
+type ConstraintsUnion []Constraints
+
+// Union returns the versions that satisfy cs or any of the other
+// constraints.
+func (cs Constraints) Union(others ...Constraints) ConstraintsUnion {
+	return ConstraintsUnion{cs}.Union(ConstraintsUnion(others))
+}
+
+// Complement returns the versions that do not satisfy cs. It is exact for
+// pre-releases only if cs uses PrereleaseInclude; see ConstraintsUnion.
+func (cs Constraints) Complement() ConstraintsUnion {
+	return ConstraintsUnion{cs}.Complement()
+}
+
+// Check tests if a version satisfies any of the constraints.
+func (u ConstraintsUnion) Check(v *Version) bool {
+	for _, cs := range u {
+		if cs.Check(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Ranges returns the versions allowed by the union as a sorted list of
+// disjoint ranges. See Constraints.Ranges.
+func (u ConstraintsUnion) Ranges() []Range {
+	var result []Range
+	for _, cs := range u {
+		result = append(result, cs.Ranges()...)
+	}
+
+	return normalizeRanges(result)
+}
+
+// Union returns the versions that satisfy u or any of the other unions.
+func (u ConstraintsUnion) Union(others ...ConstraintsUnion) ConstraintsUnion {
+	ranges := u.Ranges()
+	include := u.includesPrereleases()
+	for _, o := range others {
+		ranges = append(ranges, o.Ranges()...)
+		include = include && o.includesPrereleases()
+	}
+
+	return newConstraintsUnion(ranges).includingPrereleases(include)
+}
+
+// Complement returns the versions that do not satisfy u. It is exact for
+// pre-releases only if u uses PrereleaseInclude.
+func (u ConstraintsUnion) Complement() ConstraintsUnion {
+	return newConstraintsUnion(complementRanges(u.Ranges())).
+		includingPrereleases(u.includesPrereleases())
+}
+
+// includesPrereleases tests if all the constraints of u use
+// PrereleaseInclude, so that u is checked against its ranges only.
+func (u ConstraintsUnion) includesPrereleases() bool {
+	for _, cs := range u {
+		for _, c := range cs {
+			if c.prerelease != PrereleaseInclude {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// includingPrereleases returns u, with all its constraints set to use
+// PrereleaseInclude if include is true.
+func (u ConstraintsUnion) includingPrereleases(include bool) ConstraintsUnion {
+	if include {
+		opts := []ConstraintOption{WithPrereleasePolicy(PrereleaseInclude)}
+		for _, cs := range u {
+			applyConstraintOptions(cs, opts)
+		}
+	}
+
+	return u
+}
+
+// String returns the string format of the union, with groups separated
+// by "||".
+//
+// An empty union is returned as "< 0.0.0", and a group without any
+// constraint as ">= 0.0.0". See Range.String.
+func (u ConstraintsUnion) String() string {
+	if len(u) == 0 {
+		return "< 0.0.0"
+	}
+
+	groups := make([]string, len(u))
+	for i, cs := range u {
+		groups[i] = constraintsGroupString(cs)
+	}
+
+	return strings.Join(groups, " || ")
+}
+
+func constraintsGroupString(cs Constraints) string {
+	if len(cs) == 0 {
+		return ">= 0.0.0"
+	}
+
+	strs := make([]string, len(cs))
+	for i, c := range cs {
+		strs[i] = c.String()
+	}
+
+	return strings.Join(strs, ", ")
+}
+
+// newConstraintsUnion returns the normalized union of the given ranges.
+// Ranges only separated by a single excluded version are kept in the same
+// group using "!=".
+func newConstraintsUnion(rs []Range) ConstraintsUnion {
+	rs = normalizeRanges(rs)
+
+	var result ConstraintsUnion
+	for i := 0; i < len(rs); i++ {
+		r := rs[i]
+		var excluded []*Version
+		for i+1 < len(rs) && isHole(rs[i].Upper, rs[i+1].Lower) {
+			excluded = append(excluded, rs[i].Upper.Version)
+			i++
+		}
+		r.Upper = rs[i].Upper
+
+		result = append(result, rangeConstraints(r, excluded))
+	}
+
+	return result
+}
+
+// rangeConstraints returns the constraints matching the given non-empty
+// range, minus the excluded versions.
+func rangeConstraints(r Range, excluded []*Version) Constraints {
+	var result Constraints
+	lower, upper := r.Lower, r.Upper
+	if lower.Version != nil && upper.Version != nil && lower.Version.Equal(upper.Version) {
+		result = append(result, newConstraint(OpEqual, lower.Version))
+	} else {
+		switch {
+		case lower.Version == nil:
+		case lower.Inclusive:
+			result = append(result, newConstraint(OpGreaterThanEqual, lower.Version))
+		default:
+			result = append(result, newConstraint(OpGreaterThan, lower.Version))
+		}
+		switch {
+		case upper.Version == nil:
+		case upper.Inclusive:
+			result = append(result, newConstraint(OpLessThanEqual, upper.Version))
+		default:
+			result = append(result, newConstraint(OpLessThan, upper.Version))
+		}
+	}
+
+	for _, v := range excluded {
+		result = append(result, newConstraint(OpNotEqual, v))
+	}
+
+	return result
+}
+
+// isHole tests if a range ending at upper and a range starting at lower
+// leave exactly one version between them.
+func isHole(upper, lower Bound) bool {
+	return upper.Version != nil && lower.Version != nil &&
+		!upper.Inclusive && !lower.Inclusive &&
+		upper.Version.Equal(lower.Version)
+}
+
+// complementRanges returns the gaps between the given sorted, disjoint
+// ranges.
+func complementRanges(rs []Range) []Range {
+	var result []Range
+	var lower Bound
+	for _, r := range rs {
+		if r.Lower.Version != nil {
+			result = append(result, Range{
+				Lower: lower,
+				Upper: Bound{Version: r.Lower.Version, Inclusive: !r.Lower.Inclusive},
+			})
+		}
+		if r.Upper.Version == nil {
+			return normalizeRanges(result)
+		}
+		lower = Bound{Version: r.Upper.Version, Inclusive: !r.Upper.Inclusive}
+	}
+	result = append(result, Range{Lower: lower})
+
+	return normalizeRanges(result)
+}
@@ -0,0 +1,160 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package version
+
+import (
+	"testing"
+)
+
+func TestConstraintsUnion(t *testing.T) {
+	cases := []struct {
+		constraints []string
+		expected    string
+	}{
+		{[]string{">= 1.0, < 1.2", ">= 2.0"}, ">= 1.0.0, < 1.2.0 || >= 2.0.0"},
+		{[]string{">= 2.0", ">= 1.0, < 1.2"}, ">= 1.0.0, < 1.2.0 || >= 2.0.0"},
+		{[]string{"~> 1.0", "~> 2.0"}, ">= 1.0.0, < 3.0.0"},
+		{[]string{">= 1.0, < 1.5", "> 1.5, < 2.0"}, ">= 1.0.0, < 2.0.0, != 1.5.0"},
+		{[]string{">= 1.0, < 1.5", "> 1.5, < 2.0", "1.5"}, ">= 1.0.0, < 2.0.0"},
+		{[]string{"< 1.0", ">= 1.0"}, ">= 0.0.0"},
+		{[]string{"1.0", "1.0.0"}, "= 1.0.0"},
+		{[]string{"> 2.0, < 1.0", "> 3.0, < 1.0"}, "< 0.0.0"},
+		{[]string{"!= 1.0", "!= 2.0"}, ">= 0.0.0"},
+	}
+
+	for _, tc := range cases {
+		cs := make([]Constraints, len(tc.constraints))
+		for i, c := range tc.constraints {
+			cs[i] = MustConstraints(NewConstraint(c))
+		}
+
+		actual := cs[0].Union(cs[1:]...).String()
+		if actual != tc.expected {
+			t.Fatalf("Constraints: %q\nExpected: %q\nActual: %q",
+				tc.constraints, tc.expected, actual)
+		}
+	}
+}
+
+func TestConstraintsComplement(t *testing.T) {
+	cases := []struct {
+		constraint string
+		expected   string
+	}{
+		{">= 1.0, < 2.0", "< 1.0.0 || >= 2.0.0"},
+		{"~> 1.2.3", "< 1.2.3 || >= 1.3.0"},
+		{"~> 1", "< 1.0.0"},
+		{"!= 1.5", "= 1.5.0"},
+		{"!= 1.5, != 2.0", "= 1.5.0 || = 2.0.0"},
+		{"= 1.5", "!= 1.5.0"},
+		{"> 1.0, < 3.0, != 2.0", "<= 1.0.0 || = 2.0.0 || >= 3.0.0"},
+		{"> 2.0, < 1.0", ">= 0.0.0"},
+	}
+
+	for _, tc := range cases {
+		c := MustConstraints(NewConstraint(tc.constraint))
+
+		complement := c.Complement()
+		actual := complement.String()
+		if actual != tc.expected {
+			t.Fatalf("Constraint: %s\nExpected: %q\nActual: %q",
+				tc.constraint, tc.expected, actual)
+		}
+
+		// The complement of the complement should describe the same
+		// versions as the original constraint.
+		original := rangesString(c.Ranges())
+		back := rangesString(complement.Complement().Ranges())
+		if back != original {
+			t.Fatalf("Constraint: %s\nExpected: %q\nActual: %q",
+				tc.constraint, original, back)
+		}
+	}
+}
+
+func TestConstraintsComplement_prerelease(t *testing.T) {
+	cases := []struct {
+		policy     PrereleasePolicy
+		constraint string
+		version    string
+		check      bool
+		complement bool
+	}{
+		// Strict constraints and their complement both reject pre-releases
+		{PrereleaseStrict, ">= 1.0", "1.5.0-beta", false, false},
+		{PrereleaseStrict, ">= 1.0", "0.9.0-beta", false, false},
+		{PrereleaseStrict, ">= 1.0", "1.5.0", true, false},
+		{PrereleaseStrict, ">= 1.0", "0.9.0", false, true},
+
+		// but releases satisfy exactly one of them, even if the constraint
+		// only allows pre-releases
+		{PrereleaseStrict, "~> 1.2.0-beta", "1.2.0-beta.2", true, false},
+		{PrereleaseStrict, "~> 1.2.0-beta", "1.2.0", false, true},
+		{PrereleaseStrict, "~> 1.2.0-beta", "1.2.5", false, true},
+		{PrereleaseStrict, "~> 1.2.0-beta", "1.1.0", false, true},
+
+		// Including pre-releases, the complement is exact
+		{PrereleaseInclude, ">= 1.0", "1.5.0-beta", true, false},
+		{PrereleaseInclude, ">= 1.0", "0.9.0-beta", false, true},
+		{PrereleaseInclude, ">= 1.0", "1.0.0-beta", false, true},
+		{PrereleaseInclude, ">= 1.0", "0.9.0", false, true},
+		{PrereleaseInclude, "~> 1.2.0-beta", "1.2.0", true, false},
+		{PrereleaseInclude, "~> 1.2.0-beta", "1.3.0", false, true},
+	}
+
+	for _, tc := range cases {
+		cs := MustConstraints(NewConstraint(tc.constraint, WithPrereleasePolicy(tc.policy)))
+		v := Must(NewVersion(tc.version))
+
+		if actual := cs.Check(v); actual != tc.check {
+			t.Fatalf("%s against %s with policy %d: expected check %t", tc.version, tc.constraint, tc.policy, tc.check)
+		}
+		if actual := cs.Complement().Check(v); actual != tc.complement {
+			t.Fatalf("%s against %s with policy %d: expected complement check %t", tc.version, tc.constraint, tc.policy, tc.complement)
+		}
+	}
+
+	// A union accepts a release only if one of its sides does
+	rc := MustConstraints(NewConstraint("<= 1")).Union(MustConstraints(NewConstraint("~> 2-rc.1")))
+	if v := Must(NewVersion("2.0.0")); rc.Check(v) {
+		t.Fatalf("expected %s not to include %s", rc, v)
+	}
+
+	// The union keeps including pre-releases only if all sides do
+	include := MustConstraints(NewConstraint("< 1.0", WithPrereleasePolicy(PrereleaseInclude)))
+	strict := MustConstraints(NewConstraint(">= 2.0"))
+	beta := Must(NewVersion("0.9.0-beta"))
+	if !include.Union(include).Check(beta) {
+		t.Fatalf("expected union to include %s", beta)
+	}
+	if include.Union(strict).Check(beta) {
+		t.Fatalf("expected union not to include %s", beta)
+	}
+}
+
+func TestConstraintsUnionCheck(t *testing.T) {
+	cases := []struct {
+		constraints []string
+		version     string
+		check       bool
+	}{
+		{[]string{">= 1.0, < 1.2", ">= 2.0"}, "1.1", true},
+		{[]string{">= 1.0, < 1.2", ">= 2.0"}, "1.5", false},
+		{[]string{">= 1.0, < 1.2", ">= 2.0"}, "2.5", true},
+		{[]string{}, "1.0", false},
+	}
+
+	for _, tc := range cases {
+		u := make(ConstraintsUnion, len(tc.constraints))
+		for i, c := range tc.constraints {
+			u[i] = MustConstraints(NewConstraint(c))
+		}
+
+		actual := u.Check(Must(NewVersion(tc.version)))
+		if actual != tc.check {
+			t.Fatalf("Version: %s\nConstraints: %s\nExpected: %#v",
+				tc.version, u, tc.check)
+		}
+	}
+}
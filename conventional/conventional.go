@@ -0,0 +1,171 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+// Package conventional computes the next version of a project from its
+// commit messages, following the Conventional Commits specification:
+// https://www.conventionalcommits.org/
+package conventional
+
+import (
+	"regexp"
+	"strings"
+
+	version "github.com/hashicorp/go-version"
+)
+
+var headerRegexp = regexp.MustCompile(`^([A-Za-z]+)(?:\(([^()]*)\))?(!)?: (.+)$`)
+
+// Commit is a parsed Conventional Commits message.
+type Commit struct {
+	Type        string
+	Scope       string
+	Description string
+
+	// Breaking is true if the type is followed by "!", or the message has
+	// a BREAKING CHANGE footer.
+	Breaking bool
+}
+
+// ParseCommit parses a commit message, such as "feat(api)!: drop v1". It
+// returns false if the message does not follow Conventional Commits. The
+// type is returned in lowercase.
+func ParseCommit(message string) (*Commit, bool) {
+	lines := strings.Split(strings.TrimSpace(message), "\n")
+	m := headerRegexp.FindStringSubmatch(strings.TrimSpace(lines[0]))
+	if m == nil {
+		return nil, false
+	}
+
+	c := &Commit{
+		Type:        strings.ToLower(m[1]),
+		Scope:       m[2],
+		Description: m[4],
+		Breaking:    m[3] == "!",
+	}
+	for _, line := range lines[1:] {
+		if strings.HasPrefix(line, "BREAKING CHANGE: ") || strings.HasPrefix(line, "BREAKING-CHANGE: ") {
+			c.Breaking = true
+		}
+	}
+
+	return c, true
+}
+
+// Bump is the part of a version to increment for a release.
+type Bump int
+
+const (
+	None Bump = iota
+	Patch
+	Minor
+	Major
+)
+
+func (b Bump) String() string {
+	switch b {
+	case Patch:
+		return "patch"
+	case Minor:
+		return "minor"
+	case Major:
+		return "major"
+	default:
+		return "none"
+	}
+}
+
+// Analyze returns the bump required by the given commit messages: Major
+// if any of them is a breaking change, otherwise Minor for a "feat" and
+// Patch for a "fix". Other types, and messages that do not follow
+// Conventional Commits, do not require a release.
+func Analyze(messages []string) Bump {
+	bump := None
+	for _, msg := range messages {
+		c, ok := ParseCommit(msg)
+		if !ok {
+			continue
+		}
+
+		b := None
+		switch {
+		case c.Breaking:
+			b = Major
+		case c.Type == "feat":
+			b = Minor
+		case c.Type == "fix":
+			b = Patch
+		}
+		if b > bump {
+			bump = b
+		}
+	}
+
+	return bump
+}
+
+// Option changes how the next version is computed.
+type Option func(*options)
+
+type options struct {
+	channel string
+}
+
+// WithPrerelease is an Option that releases the next version as a
+// pre-release of the given channel, numbered like "rc.1". Next returns an
+// error if the channel is not valid; see version.ValidatePrereleaseChannel.
+func WithPrerelease(channel string) Option {
+	return func(o *options) {
+		o.channel = channel
+	}
+}
+
+// Next returns the version to release after current, given the messages
+// of the commits since current was released. It returns current if none
+// of the commits requires a release.
+//
+// While the major version is 0, a breaking change only bumps the minor
+// version, as the API is not considered stable yet.
+//
+// If current is a pre-release, the pending release is used when it covers
+// the commits: after 1.3.0-rc.1, a fix leads to 1.3.0, or 1.3.0-rc.2 with
+// WithPrerelease("rc"), while a breaking change leads to 2.0.0 or
+// 2.0.0-rc.1.
+func Next(current *version.Version, messages []string, opts ...Option) (*version.Version, error) {
+	o := &options{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	if o.channel != "" {
+		if err := version.ValidatePrereleaseChannel(o.channel); err != nil {
+			return nil, err
+		}
+	}
+
+	bump := Analyze(messages)
+	if bump == Major && current.Segments64()[0] == 0 {
+		bump = Minor
+	}
+
+	var next *version.Version
+	switch bump {
+	case Major:
+		next = current.BumpMajor()
+	case Minor:
+		next = current.BumpMinor()
+	case Patch:
+		next = current.BumpPatch()
+	default:
+		return current, nil
+	}
+
+	if o.channel == "" {
+		return next, nil
+	}
+	if current.Prerelease() != "" && next.Equal(current.Core()) {
+		return current.BumpPrerelease(o.channel)
+	}
+
+	return version.NewVersion(next.String() + "-" + o.channel + ".1")
+}
@@ -0,0 +1,141 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package conventional
+
+import (
+	"reflect"
+	"testing"
+
+	version "github.com/hashicorp/go-version"
+)
+
+func TestParseCommit(t *testing.T) {
+	cases := []struct {
+		message  string
+		expected *Commit
+	}{
+		{"feat: add login", &Commit{Type: "feat", Description: "add login"}},
+		{"Fix(auth): handle expiry\n\nDetails.", &Commit{Type: "fix", Scope: "auth", Description: "handle expiry"}},
+		{"refactor!: drop v1 API", &Commit{Type: "refactor", Description: "drop v1 API", Breaking: true}},
+		{"feat(api)!: new paths", &Commit{Type: "feat", Scope: "api", Description: "new paths", Breaking: true}},
+		{
+			"fix: rename flag\n\nBREAKING CHANGE: -v is now -verbose",
+			&Commit{Type: "fix", Description: "rename flag", Breaking: true},
+		},
+		{
+			"chore: update deps\n\nBREAKING-CHANGE: requires Go 1.16",
+			&Commit{Type: "chore", Description: "update deps", Breaking: true},
+		},
+		{"fix: typo\n\nmentions BREAKING CHANGE: in a sentence", &Commit{Type: "fix", Description: "typo"}},
+		{"Merge branch 'main'", nil},
+		{"feat:missing space", nil},
+		{"", nil},
+	}
+
+	for _, tc := range cases {
+		actual, ok := ParseCommit(tc.message)
+		if ok != (tc.expected != nil) {
+			t.Fatalf("%q: unexpected result %v", tc.message, ok)
+		}
+		if !reflect.DeepEqual(actual, tc.expected) {
+			t.Fatalf("%q\nexpected: %#v\nactual: %#v", tc.message, tc.expected, actual)
+		}
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	cases := []struct {
+		messages []string
+		expected Bump
+	}{
+		{nil, None},
+		{[]string{"docs: readme", "chore: ci", "not conventional"}, None},
+		{[]string{"docs: readme", "fix: crash"}, Patch},
+		{[]string{"fix: crash", "feat: new flag", "fix: leak"}, Minor},
+		{[]string{"feat: new flag", "docs!: drop old docs"}, Major},
+	}
+
+	for _, tc := range cases {
+		if actual := Analyze(tc.messages); actual != tc.expected {
+			t.Fatalf("%#v: expected %s, got %s", tc.messages, tc.expected, actual)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	fix := []string{"fix: crash"}
+	feat := []string{"feat: new flag", "fix: crash"}
+	breaking := []string{"feat!: new config format"}
+
+	cases := []struct {
+		current  string
+		messages []string
+		channel  string
+		expected string
+	}{
+		{"1.2.3", nil, "", "1.2.3"},
+		{"1.2.3", fix, "", "1.2.4"},
+		{"1.2.3", feat, "", "1.3.0"},
+		{"1.2.3", breaking, "", "2.0.0"},
+		{"v1.2.3+ent", fix, "", "1.2.4"},
+
+		// Breaking changes only bump the minor version of 0.x
+		{"0.4.1", breaking, "", "0.5.0"},
+		{"0.4.1", feat, "", "0.5.0"},
+		{"0.4.1", fix, "", "0.4.2"},
+
+		// Pre-release channels
+		{"1.2.3", fix, "rc", "1.2.4-rc.1"},
+		{"1.2.3", feat, "rc", "1.3.0-rc.1"},
+		{"1.3.0-rc.1", fix, "rc", "1.3.0-rc.2"},
+		{"1.3.0-rc.1", feat, "rc", "1.3.0-rc.2"},
+		{"1.3.0-rc.2", breaking, "rc", "2.0.0-rc.1"},
+		{"1.3.0-beta.4", fix, "rc", "1.3.0-rc.1"},
+		{"1.3.1-rc.1", feat, "rc", "1.4.0-rc.1"},
+		{"0.9.0-rc.1", breaking, "rc", "0.9.0-rc.2"},
+		{"1.3.0-rc.1", nil, "rc", "1.3.0-rc.1"},
+
+		// Releasing a pre-release
+		{"1.3.0-rc.2", fix, "", "1.3.0"},
+		{"1.3.0-rc.2", feat, "", "1.3.0"},
+		{"1.3.0-rc.2", breaking, "", "2.0.0"},
+	}
+
+	for _, tc := range cases {
+		current := version.Must(version.NewVersion(tc.current))
+
+		var opts []Option
+		if tc.channel != "" {
+			opts = append(opts, WithPrerelease(tc.channel))
+		}
+
+		actual, err := Next(current, tc.messages, opts...)
+		if err != nil {
+			t.Fatalf("%s %#v %q: err: %s", tc.current, tc.messages, tc.channel, err)
+		}
+		if actual.String() != tc.expected {
+			t.Fatalf("%s %#v %q: expected %s, got %s", tc.current, tc.messages, tc.channel, tc.expected, actual)
+		}
+	}
+}
+
+func TestNext_invalidChannel(t *testing.T) {
+	cases := []struct {
+		current string
+		channel string
+	}{
+		{"1.2.3", "rc_1"},
+		{"1.3.0-rc.1", "rc_1"},
+		{"1.2.3", "rc..1"},
+		{"1.2.3", "rc+1"},
+		{"1.2.3", "rc~1"},
+	}
+
+	for _, tc := range cases {
+		current := version.Must(version.NewVersion(tc.current))
+		if v, err := Next(current, []string{"feat: x"}, WithPrerelease(tc.channel)); err == nil {
+			t.Fatalf("%s %q: expected error, got %s", tc.current, tc.channel, v)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+// Package feature decides which named features of a peer can be used,
+// given the versions of the peer that support each of them.
+package feature
+
+import (
+	"fmt"
+	"sort"
+
+	version "github.com/hashicorp/go-version"
+)
+
+// Registry maps named features to the versions that support them.
+type Registry struct {
+	features map[string]version.Constraints
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{features: make(map[string]version.Constraints)}
+}
+
+// Add registers a feature supported by the versions that satisfy the
+// constraints, such as ">= 1.4" for a feature added in 1.4 or
+// ">= 1.4, < 2.0" for one removed in 2.0. Adding a feature that is already
+// registered replaces its constraints.
+func (r *Registry) Add(name string, cs version.Constraints) {
+	r.features[name] = cs
+}
+
+// Names returns the names of the registered features, sorted.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.features))
+	for name := range r.features {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Enabled returns the sorted names of the features supported by the
+// given version of the peer.
+func (r *Registry) Enabled(peer *version.Version) []string {
+	var result []string
+	for _, name := range r.Names() {
+		if r.features[name].Check(peer) {
+			result = append(result, name)
+		}
+	}
+
+	return result
+}
+
+// Check returns nil if the named feature is supported by the given
+// version of the peer, or a *MissingError explaining why it is not.
+func (r *Registry) Check(name string, peer *version.Version) error {
+	cs, ok := r.features[name]
+	if ok && cs.Check(peer) {
+		return nil
+	}
+
+	return &MissingError{Feature: name, Version: peer, Constraints: cs}
+}
+
+// Missing returns an error for each registered feature that is not
+// supported by the given version of the peer, sorted by name.
+func (r *Registry) Missing(peer *version.Version) []*MissingError {
+	var result []*MissingError
+	for _, name := range r.Names() {
+		if err := r.Check(name, peer); err != nil {
+			result = append(result, err.(*MissingError))
+		}
+	}
+
+	return result
+}
+
+// MissingError is returned for a feature that is not supported by the
+// version of a peer. Constraints is nil if the feature is not registered.
+type MissingError struct {
+	Feature     string
+	Version     *version.Version
+	Constraints version.Constraints
+}
+
+func (e *MissingError) Error() string {
+	if e.Constraints == nil {
+		return fmt.Sprintf("unknown feature %q", e.Feature)
+	}
+
+	msg := fmt.Sprintf("feature %q is not available in %s", e.Feature, e.Version)
+
+	ranges := e.Constraints.Ranges()
+	if len(ranges) == 0 {
+		return msg + ": not available in any version"
+	}
+
+	first, last := ranges[0], ranges[len(ranges)-1]
+	switch {
+	case first.Lower.Version != nil && !first.Contains(e.Version) && e.Version.LessThanOrEqual(first.Lower.Version):
+		if first.Lower.Inclusive {
+			return fmt.Sprintf("%s: added in %s", msg, first.Lower.Version)
+		}
+		return fmt.Sprintf("%s: added after %s", msg, first.Lower.Version)
+	case last.Upper.Version != nil && !last.Contains(e.Version) && e.Version.GreaterThanOrEqual(last.Upper.Version):
+		if last.Upper.Inclusive {
+			return fmt.Sprintf("%s: removed after %s", msg, last.Upper.Version)
+		}
+		return fmt.Sprintf("%s: removed in %s", msg, last.Upper.Version)
+	default:
+		return fmt.Sprintf("%s: requires %s", msg, e.Constraints)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package feature
+
+import (
+	"reflect"
+	"testing"
+
+	version "github.com/hashicorp/go-version"
+)
+
+func testRegistry() *Registry {
+	r := NewRegistry()
+	r.Add("streaming", version.MustConstraints(version.NewConstraint(">= 1.4")))
+	r.Add("legacy-auth", version.MustConstraints(version.NewConstraint("< 2.0")))
+	r.Add("batch", version.MustConstraints(version.NewConstraint(">= 1.2, < 3.0, != 1.5.0")))
+	r.Add("fast-path", version.MustConstraints(version.NewConstraint("> 1.6.0, <= 2.1.0")))
+
+	return r
+}
+
+func TestRegistryEnabled(t *testing.T) {
+	cases := []struct {
+		version  string
+		expected []string
+	}{
+		{"1.0", []string{"legacy-auth"}},
+		{"1.4.0", []string{"batch", "legacy-auth", "streaming"}},
+		{"1.5.0", []string{"legacy-auth", "streaming"}},
+		{"2.0.0", []string{"batch", "fast-path", "streaming"}},
+		{"3.0.0", []string{"streaming"}},
+	}
+
+	r := testRegistry()
+	for _, tc := range cases {
+		actual := r.Enabled(version.Must(version.NewVersion(tc.version)))
+		if !reflect.DeepEqual(actual, tc.expected) {
+			t.Fatalf("%s\nexpected: %#v\nactual: %#v", tc.version, tc.expected, actual)
+		}
+	}
+}
+
+func TestRegistryCheck(t *testing.T) {
+	cases := []struct {
+		feature string
+		version string
+		err     string
+	}{
+		{"streaming", "1.4.0", ""},
+		{"streaming", "1.3.9", `feature "streaming" is not available in 1.3.9: added in 1.4.0`},
+		{"legacy-auth", "2.0.0", `feature "legacy-auth" is not available in 2.0.0: removed in 2.0.0`},
+		{"batch", "1.5.0", `feature "batch" is not available in 1.5.0: requires >= 1.2, < 3.0, != 1.5.0`},
+		{"fast-path", "1.6.0", `feature "fast-path" is not available in 1.6.0: added after 1.6.0`},
+		{"fast-path", "2.2.0", `feature "fast-path" is not available in 2.2.0: removed after 2.1.0`},
+		{"teleport", "1.0.0", `unknown feature "teleport"`},
+	}
+
+	r := testRegistry()
+	for _, tc := range cases {
+		err := r.Check(tc.feature, version.Must(version.NewVersion(tc.version)))
+		if tc.err == "" {
+			if err != nil {
+				t.Fatalf("%s %s: unexpected error: %s", tc.feature, tc.version, err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != tc.err {
+			t.Fatalf("%s %s\nexpected: %s\nactual: %v", tc.feature, tc.version, tc.err, err)
+		}
+	}
+}
+
+func TestRegistryMissing(t *testing.T) {
+	r := testRegistry()
+	missing := r.Missing(version.Must(version.NewVersion("3.0.0")))
+
+	var actual []string
+	for _, err := range missing {
+		actual = append(actual, err.Feature)
+	}
+
+	expected := []string{"batch", "fast-path", "legacy-auth"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected: %#v\nactual: %#v", expected, actual)
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package gittag
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	version "github.com/hashicorp/go-version"
+)
+
+const dirtySuffix = "-dirty"
+
+var describeRegexp = regexp.MustCompile(`^(.+)-([0-9]+)-g([0-9a-f]{4,64})$`)
+
+// Describe is the output of "git describe --tags", such as
+// "v1.4.2-17-gabc1234-dirty" for a build 17 commits after the tag v1.4.2,
+// at commit abc1234 with uncommitted changes.
+type Describe struct {
+	// Tag is the version of the closest tag.
+	Tag *version.Version
+
+	// Distance is the number of commits since the tag, and Hash the
+	// abbreviated hash of the current commit. Hash is empty if the
+	// output is only the tag, as for a build of the tagged commit.
+	Distance int
+	Hash     string
+
+	// Dirty is true if the working tree had uncommitted changes, which
+	// "git describe --dirty" marks with a "-dirty" suffix.
+	Dirty bool
+}
+
+// ParseDescribe parses the output of "git describe --tags", optionally
+// with --long or --dirty. The tag is parsed with version.NewVersion and
+// the given options.
+//
+// Unlike parsing the whole output with version.NewVersion, which reads
+// "1.4.2-17-gabc1234" as a pre-release of 1.4.2, this keeps the commit
+// distance apart so that builds sort after their tag. See Compare.
+func ParseDescribe(s string, opts ...version.Option) (*Describe, error) {
+	d := &Describe{}
+
+	rest := strings.TrimSpace(s)
+	if strings.HasSuffix(rest, dirtySuffix) {
+		d.Dirty = true
+		rest = strings.TrimSuffix(rest, dirtySuffix)
+	}
+
+	if m := describeRegexp.FindStringSubmatch(rest); m != nil {
+		distance, err := strconv.Atoi(m[2])
+		if err != nil {
+			return nil, fmt.Errorf("malformed git describe output %q: %s", s, err)
+		}
+
+		rest, d.Distance, d.Hash = m[1], distance, m[3]
+	}
+
+	tag, err := version.NewVersion(rest, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("malformed git describe output %q: %s", s, err)
+	}
+	d.Tag = tag
+
+	return d, nil
+}
+
+// Compare compares this build to another one. It orders builds by tag,
+// then by distance from the tag, and then a dirty build after a clean
+// one. The hashes are not compared, as they are not ordered.
+func (d *Describe) Compare(o *Describe) int {
+	if cmp := d.Tag.Compare(o.Tag); cmp != 0 {
+		return cmp
+	}
+
+	switch {
+	case d.Distance != o.Distance:
+		if d.Distance < o.Distance {
+			return -1
+		}
+		return 1
+	case d.Dirty != o.Dirty:
+		if o.Dirty {
+			return -1
+		}
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Version returns a SemVer version for the build, which sorts after its
+// tag and before any other version:
+//
+//	v1.4.2                   -> 1.4.2
+//	v1.4.2-17-gabc1234       -> 1.4.3-0.dev.17+gabc1234
+//	v1.4.2-17-gabc1234-dirty -> 1.4.3-0.dev.17+gabc1234.dirty
+//	v1.5.0-rc.1-3-gabc1234   -> 1.5.0-rc.1.0.dev.3+gabc1234
+//
+// The numeric "0" identifier sorts before any pre-release of the next
+// version, such as 1.4.3-alpha, both in SemVer and with Compare.
+//
+// A build of a tag without changes is the version of the tag. Since the
+// hash and dirty flag are metadata, a dirty build has the same precedence
+// as the clean build of the same commit.
+func (d *Describe) Version() *version.Version {
+	if d.Distance == 0 && !d.Dirty {
+		return d.Tag
+	}
+
+	var base string
+	if pre := d.Tag.Prerelease(); pre != "" {
+		base = segmentsString(d.Tag.Segments64()) + "-" + pre + "."
+	} else {
+		base = segmentsString(d.Tag.BumpPatch().Segments64()) + "-"
+	}
+
+	var metadata []string
+	if d.Hash != "" {
+		metadata = append(metadata, "g"+d.Hash)
+	}
+	if d.Dirty {
+		metadata = append(metadata, "dirty")
+	}
+
+	s := fmt.Sprintf("%s0.dev.%d", base, d.Distance)
+	if len(metadata) > 0 {
+		s += "+" + strings.Join(metadata, ".")
+	}
+
+	return version.Must(version.NewSemver(s))
+}
+
+// String returns the describe output of the build, such as
+// "v1.4.2-17-gabc1234-dirty".
+func (d *Describe) String() string {
+	s := d.Tag.Original()
+	if d.Hash != "" {
+		s += fmt.Sprintf("-%d-g%s", d.Distance, d.Hash)
+	}
+	if d.Dirty {
+		s += dirtySuffix
+	}
+
+	return s
+}
+
+func segmentsString(segments []int64) string {
+	strs := make([]string, len(segments))
+	for i, s := range segments {
+		strs[i] = strconv.FormatInt(s, 10)
+	}
+
+	return strings.Join(strs, ".")
+}
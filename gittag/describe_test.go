@@ -0,0 +1,100 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package gittag
+
+import (
+	"testing"
+
+	version "github.com/hashicorp/go-version"
+)
+
+func TestParseDescribe(t *testing.T) {
+	cases := []struct {
+		input    string
+		tag      string
+		distance int
+		hash     string
+		dirty    bool
+		version  string
+		err      bool
+	}{
+		{"v1.4.2", "v1.4.2", 0, "", false, "v1.4.2", false},
+		{"v1.4.2-17-gabc1234", "v1.4.2", 17, "abc1234", false, "1.4.3-0.dev.17+gabc1234", false},
+		{"v1.4.2-17-gabc1234-dirty\n", "v1.4.2", 17, "abc1234", true, "1.4.3-0.dev.17+gabc1234.dirty", false},
+		{"v1.4.2-0-gabc1234", "v1.4.2", 0, "abc1234", false, "v1.4.2", false},
+		{"v1.4.2-dirty", "v1.4.2", 0, "", true, "1.4.3-0.dev.0+dirty", false},
+		{"v1.5.0-rc.1-3-gabc1234", "v1.5.0-rc.1", 3, "abc1234", false, "1.5.0-rc.1.0.dev.3+gabc1234", false},
+		{"1.2.3.4-2-g0123456789abcdef", "1.2.3.4", 2, "0123456789abcdef", false, "1.2.4.0-0.dev.2+g0123456789abcdef", false},
+		{"nightly-3-gabc1234", "", 0, "", false, "", true},
+		{"", "", 0, "", false, "", true},
+	}
+
+	for _, tc := range cases {
+		d, err := ParseDescribe(tc.input)
+		if (err != nil) != tc.err {
+			t.Fatalf("%q: unexpected error: %v", tc.input, err)
+		}
+		if err != nil {
+			continue
+		}
+
+		if d.Tag.Original() != tc.tag || d.Distance != tc.distance || d.Hash != tc.hash || d.Dirty != tc.dirty {
+			t.Fatalf("%q: bad result: %#v", tc.input, d)
+		}
+		if actual := d.Version().Original(); actual != tc.version {
+			t.Fatalf("%q: expected version %q, got %q", tc.input, tc.version, actual)
+		}
+	}
+}
+
+func TestParseDescribe_prefix(t *testing.T) {
+	d, err := ParseDescribe("release/v2.0.0-4-gdeadbeef", version.WithPrefix("release/"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Tag.String() != "2.0.0" || d.Distance != 4 {
+		t.Fatalf("bad result: %#v", d)
+	}
+	if actual := d.String(); actual != "release/v2.0.0-4-gdeadbeef" {
+		t.Fatalf("bad string: %s", actual)
+	}
+}
+
+func TestDescribeCompare(t *testing.T) {
+	// Each build sorts before the next one
+	builds := []string{
+		"v1.4.1-30-gfffffff",
+		"v1.4.2",
+		"v1.4.2-dirty",
+		"v1.4.2-2-gbbbbbbb",
+		"v1.4.2-17-gaaaaaaa",
+		"v1.4.2-17-gaaaaaaa-dirty",
+		"v1.4.3-alpha",
+		"v1.4.3-rc.1",
+		"v1.4.3-rc.1-1-gccccccc",
+		"v1.4.3",
+	}
+
+	for i := 0; i < len(builds)-1; i++ {
+		a, err := ParseDescribe(builds[i])
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		b, err := ParseDescribe(builds[i+1])
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		if a.Compare(b) != -1 || b.Compare(a) != 1 || a.Compare(a) != 0 {
+			t.Fatalf("%s should sort before %s", a, b)
+		}
+		// Dirty builds only differ by metadata, which is not ordered
+		if a.Version().GreaterThan(b.Version()) {
+			t.Fatalf("%s should not sort after %s", a.Version(), b.Version())
+		}
+		if a.String() != builds[i] {
+			t.Fatalf("bad string: %s", a)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+// Package gittag reads versions from the tags of a local git repository,
+// without running git, and from the output of git describe.
+package gittag
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	version "github.com/hashicorp/go-version"
+)
+
+const tagsPrefix = "refs/tags/"
+
+// Names returns the names of the tags of the repository at path, sorted.
+// The path is either a working tree, containing a .git directory or file,
+// or a git directory such as a bare repository.
+//
+// Both loose refs and the packed-refs file are read.
+func Names(path string) ([]string, error) {
+	gitDir, err := findGitDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var names []string
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	tagsDir := filepath.Join(gitDir, filepath.FromSlash(tagsPrefix))
+	err = filepath.Walk(tagsDir, func(p string, info os.FileInfo, err error) error {
+		if os.IsNotExist(err) && p == tagsDir {
+			return nil
+		}
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(tagsDir, p)
+		if err != nil {
+			return err
+		}
+		add(filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading tags: %s", err)
+	}
+
+	packed, err := readPackedTags(filepath.Join(gitDir, "packed-refs"))
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range packed {
+		add(name)
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
+
+// Tags returns the versions of the tags of the repository at path, sorted
+// from lowest to highest. Each tag is parsed with version.NewVersion and
+// the given options, such as version.WithPrefix("v"), and the tags that
+// fail to parse are ignored. The name of the tag of a version is its
+// Original. See Names for the path.
+func Tags(path string, opts ...version.Option) (version.Collection, error) {
+	names, err := Names(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var result version.Collection
+	for _, name := range names {
+		if v, err := version.NewVersion(name, opts...); err == nil {
+			result = append(result, v)
+		}
+	}
+	sort.Stable(result)
+
+	return result, nil
+}
+
+// Latest returns the highest version of the tags of the repository at path
+// that satisfies the constraints, or nil if there is none. See Tags for
+// the path and options.
+func Latest(path string, cs version.Constraints, opts ...version.Option) (*version.Version, error) {
+	tags, err := Tags(path, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return version.Select(tags, cs, version.SelectHighest), nil
+}
+
+// findGitDir returns the git directory of the repository at path. The
+// common directory is returned for linked worktrees, as that is where the
+// tags are.
+func findGitDir(path string) (string, error) {
+	gitDir := path
+	dotGit := filepath.Join(path, ".git")
+	if info, err := os.Stat(dotGit); err == nil {
+		gitDir = dotGit
+		if !info.IsDir() {
+			// A .git file points to the git directory of a worktree or
+			// submodule, such as "gitdir: ../.git/worktrees/name"
+			content, err := ioutil.ReadFile(dotGit)
+			if err != nil {
+				return "", err
+			}
+			line := strings.TrimSpace(string(content))
+			if !strings.HasPrefix(line, "gitdir: ") {
+				return "", fmt.Errorf("malformed .git file: %s", dotGit)
+			}
+			gitDir = strings.TrimPrefix(line, "gitdir: ")
+			if !filepath.IsAbs(gitDir) {
+				gitDir = filepath.Join(path, gitDir)
+			}
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(gitDir, "HEAD")); err != nil {
+		return "", fmt.Errorf("not a git repository: %s", path)
+	}
+
+	if content, err := ioutil.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		common := strings.TrimSpace(string(content))
+		if !filepath.IsAbs(common) {
+			common = filepath.Join(gitDir, common)
+		}
+		gitDir = common
+	}
+
+	return gitDir, nil
+}
+
+// readPackedTags returns the names of the tags in a packed-refs file, if
+// there is one.
+func readPackedTags(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var names []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// Lines are "<hash> <ref>", with comments starting with "#" and
+		// peeled tags with "^"
+		line := scanner.Text()
+		if line == "" || line[0] == '#' || line[0] == '^' {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 2 && strings.HasPrefix(fields[1], tagsPrefix) {
+			names = append(names, strings.TrimPrefix(fields[1], tagsPrefix))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading %s: %s", path, err)
+	}
+
+	return names, nil
+}
@@ -0,0 +1,142 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package gittag
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	version "github.com/hashicorp/go-version"
+)
+
+const fixture = "testdata/repo.git"
+
+func originals(vs version.Collection) []string {
+	result := make([]string, len(vs))
+	for i, v := range vs {
+		result[i] = v.Original()
+	}
+
+	return result
+}
+
+func TestNames(t *testing.T) {
+	names, err := Names(fixture)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := []string{
+		"nightly", "release/v3.0.0", "v0.9", "v1.0.0", "v1.1.0",
+		"v1.10.0", "v1.2.0", "v2.0.0-rc.1",
+	}
+	if !reflect.DeepEqual(names, expected) {
+		t.Fatalf("expected: %#v\nactual: %#v", expected, names)
+	}
+}
+
+func TestTags(t *testing.T) {
+	cases := []struct {
+		opts     []version.Option
+		expected []string
+	}{
+		{
+			nil,
+			[]string{"v0.9", "v1.0.0", "v1.1.0", "v1.2.0", "v1.10.0", "v2.0.0-rc.1"},
+		},
+		{
+			[]version.Option{version.WithPrefix("release/")},
+			[]string{"release/v3.0.0"},
+		},
+	}
+
+	for _, tc := range cases {
+		tags, err := Tags(fixture, tc.opts...)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		if actual := originals(tags); !reflect.DeepEqual(actual, tc.expected) {
+			t.Fatalf("expected: %#v\nactual: %#v", tc.expected, actual)
+		}
+	}
+}
+
+func TestLatest(t *testing.T) {
+	cases := []struct {
+		constraint string
+		expected   string
+	}{
+		{">= 0", "v1.10.0"},
+		{"~> 1.1.0", "v1.1.0"},
+		{">= 2.0.0-rc.1", "v2.0.0-rc.1"},
+		{">= 3.0", ""},
+	}
+
+	for _, tc := range cases {
+		cs := version.MustConstraints(version.NewConstraint(tc.constraint))
+		v, err := Latest(fixture, cs)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		actual := ""
+		if v != nil {
+			actual = v.Original()
+		}
+		if actual != tc.expected {
+			t.Fatalf("%s: expected %q, got %q", tc.constraint, tc.expected, actual)
+		}
+	}
+}
+
+func TestNames_worktree(t *testing.T) {
+	fixtureDir, err := filepath.Abs(fixture)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// A linked worktree has a .git file pointing to its own git directory,
+	// which points to the common directory holding the tags.
+	dir, err := ioutil.TempDir("", "gittag")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	worktreeGitDir := filepath.Join(dir, "worktrees", "feature")
+	if err := os.MkdirAll(worktreeGitDir, 0755); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	files := map[string]string{
+		filepath.Join(worktreeGitDir, "HEAD"):      "ref: refs/heads/feature\n",
+		filepath.Join(worktreeGitDir, "commondir"): fixtureDir + "\n",
+		filepath.Join(dir, "tree", ".git"):         "gitdir: ../worktrees/feature\n",
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+
+	names, err := Names(filepath.Join(dir, "tree"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(names) != 8 {
+		t.Fatalf("bad names: %#v", names)
+	}
+}
+
+func TestNames_notRepository(t *testing.T) {
+	if _, err := Names("testdata"); err == nil {
+		t.Fatal("expected error")
+	}
+}
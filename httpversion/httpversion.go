@@ -0,0 +1,147 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+// Package httpversion routes HTTP requests by the API version given in
+// their Api-Version header.
+package httpversion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	version "github.com/hashicorp/go-version"
+)
+
+const (
+	// Header is the request header giving the API version to use, which
+	// is also set on responses to the version that was served.
+	Header = "Api-Version"
+
+	// SupportedHeader is set on rejected responses to the constraints of
+	// the supported API versions, with routes separated by "||".
+	SupportedHeader = "Api-Supported-Versions"
+)
+
+// Error codes of the error body.
+const (
+	CodeMissingVersion     = "missing_api_version"
+	CodeInvalidVersion     = "invalid_api_version"
+	CodeUnsupportedVersion = "unsupported_api_version"
+)
+
+// ErrorBody is the JSON body of the responses to rejected requests.
+type ErrorBody struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// ErrorDetail describes why a request was rejected.
+type ErrorDetail struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	Supported string `json:"supported"`
+}
+
+// Route is a handler serving the API versions that satisfy its
+// constraints.
+type Route struct {
+	Constraints version.Constraints
+	Handler     http.Handler
+}
+
+// Option changes the behavior of a Handler.
+type Option func(*handler)
+
+// WithDefault is an Option that serves requests without an Api-Version
+// header as if they asked for the given version. Otherwise they are
+// rejected.
+func WithDefault(v *version.Version) Option {
+	return func(h *handler) {
+		h.defaultVersion = v
+	}
+}
+
+type handler struct {
+	routes         []Route
+	supported      string
+	defaultVersion *version.Version
+}
+
+type contextKey struct{}
+
+// Handler returns a handler that parses the Api-Version header of each
+// request with version.NewVersion and serves it with the first route
+// whose constraints it satisfies. The version is available to that route
+// with FromContext, and is set in the Api-Version header of the response.
+//
+// Requests without a valid and supported version are rejected with
+// http.StatusBadRequest and an ErrorBody.
+func Handler(routes []Route, opts ...Option) http.Handler {
+	h := &handler{routes: routes}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(h)
+		}
+	}
+
+	supported := make([]string, len(routes))
+	for i, r := range routes {
+		supported[i] = r.Constraints.String()
+	}
+	h.supported = strings.Join(supported, " || ")
+
+	return h
+}
+
+// Require returns a handler that serves the requests for the API versions
+// that satisfy cs with next, and rejects the others. It is the same as
+// Handler with a single route.
+func Require(cs version.Constraints, next http.Handler, opts ...Option) http.Handler {
+	return Handler([]Route{{Constraints: cs, Handler: next}}, opts...)
+}
+
+// FromContext returns the API version of the request served by a Handler,
+// or nil if there is none.
+func FromContext(ctx context.Context) *version.Version {
+	v, _ := ctx.Value(contextKey{}).(*version.Version)
+	return v
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v := h.defaultVersion
+	if raw := r.Header.Get(Header); raw != "" {
+		var err error
+		v, err = version.NewVersion(raw)
+		if err != nil {
+			h.reject(w, CodeInvalidVersion, fmt.Sprintf("invalid API version %q: %s", raw, err))
+			return
+		}
+	} else if v == nil {
+		h.reject(w, CodeMissingVersion, fmt.Sprintf("missing %s header", Header))
+		return
+	}
+
+	for _, route := range h.routes {
+		if route.Constraints.Check(v) {
+			w.Header().Set(Header, v.Original())
+			route.Handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey{}, v)))
+			return
+		}
+	}
+
+	h.reject(w, CodeUnsupportedVersion, fmt.Sprintf("unsupported API version %s", v.Original()))
+}
+
+func (h *handler) reject(w http.ResponseWriter, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(SupportedHeader, h.supported)
+	w.WriteHeader(http.StatusBadRequest)
+
+	json.NewEncoder(w).Encode(&ErrorBody{Error: ErrorDetail{
+		Code:      code,
+		Message:   message,
+		Supported: h.supported,
+	}})
+}
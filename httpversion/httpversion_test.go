@@ -0,0 +1,140 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package httpversion
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	version "github.com/hashicorp/go-version"
+)
+
+func routeHandler(name string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s %s", name, FromContext(r.Context()))
+	})
+}
+
+func testHandler(opts ...Option) http.Handler {
+	return Handler([]Route{
+		{
+			Constraints: version.MustConstraints(version.NewConstraint(">= 1.0, < 2.0")),
+			Handler:     routeHandler("v1"),
+		},
+		{
+			Constraints: version.MustConstraints(version.NewConstraint("~> 3.1")),
+			Handler:     routeHandler("v3"),
+		},
+	}, opts...)
+}
+
+func TestHandler(t *testing.T) {
+	cases := []struct {
+		header  string
+		body    string
+		version string
+	}{
+		{"1.0", "v1 1.0.0", "1.0"},
+		{"1.9.2", "v1 1.9.2", "1.9.2"},
+		{"v3.4", "v3 3.4.0", "v3.4"},
+	}
+
+	h := testHandler()
+	for _, tc := range cases {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set(Header, tc.header)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected status 200, got %d", tc.header, rec.Code)
+		}
+		if actual := rec.Body.String(); actual != tc.body {
+			t.Fatalf("%s: expected body %q, got %q", tc.header, tc.body, actual)
+		}
+		if actual := rec.Header().Get(Header); actual != tc.version {
+			t.Fatalf("%s: expected %s header %q, got %q", tc.header, Header, tc.version, actual)
+		}
+	}
+}
+
+func TestHandler_reject(t *testing.T) {
+	cases := []struct {
+		header  string
+		code    string
+		message string
+	}{
+		{"", CodeMissingVersion, "missing Api-Version header"},
+		{"latest", CodeInvalidVersion, `invalid API version "latest": malformed version: latest`},
+		{"2.0", CodeUnsupportedVersion, "unsupported API version 2.0"},
+		{"4.0.0", CodeUnsupportedVersion, "unsupported API version 4.0.0"},
+	}
+
+	const supported = ">= 1.0, < 2.0 || ~> 3.1"
+
+	h := testHandler()
+	for _, tc := range cases {
+		req := httptest.NewRequest("GET", "/", nil)
+		if tc.header != "" {
+			req.Header.Set(Header, tc.header)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("%q: expected status 400, got %d", tc.header, rec.Code)
+		}
+		if actual := rec.Header().Get("Content-Type"); actual != "application/json" {
+			t.Fatalf("%q: expected JSON content type, got %q", tc.header, actual)
+		}
+		if actual := rec.Header().Get(SupportedHeader); actual != supported {
+			t.Fatalf("%q: expected %s header %q, got %q", tc.header, SupportedHeader, supported, actual)
+		}
+
+		var body ErrorBody
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("%q: error decoding body: %s", tc.header, err)
+		}
+		expected := ErrorDetail{Code: tc.code, Message: tc.message, Supported: supported}
+		if body.Error != expected {
+			t.Fatalf("%q\nexpected: %#v\nactual: %#v", tc.header, expected, body.Error)
+		}
+	}
+}
+
+func TestHandler_default(t *testing.T) {
+	h := testHandler(WithDefault(version.Must(version.NewVersion("1.2"))))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	if actual := rec.Body.String(); actual != "v1 1.2.0" {
+		t.Fatalf("bad body: %q", actual)
+	}
+	if actual := rec.Header().Get(Header); actual != "1.2" {
+		t.Fatalf("bad %s header: %q", Header, actual)
+	}
+}
+
+func TestRequire(t *testing.T) {
+	h := Require(version.MustConstraints(version.NewConstraint(">= 2.0")), routeHandler("v2"))
+
+	for header, code := range map[string]int{"2.1": http.StatusOK, "1.9": http.StatusBadRequest} {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set(Header, header)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		if rec.Code != code {
+			t.Fatalf("%s: expected status %d, got %d", header, code, rec.Code)
+		}
+	}
+}
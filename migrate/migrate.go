@@ -0,0 +1,155 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+// Package migrate runs the steps upgrading versioned data, such as state
+// files or database schemas, from the version it was stored with to a
+// target version.
+package migrate
+
+import (
+	"fmt"
+	"sort"
+
+	version "github.com/hashicorp/go-version"
+)
+
+// Step upgrades data from one version to the next.
+type Step struct {
+	From *version.Version
+	To   *version.Version
+	Run  func() error
+}
+
+func (s *Step) String() string {
+	return fmt.Sprintf("%s -> %s", s.From, s.To)
+}
+
+// Registry holds the steps of a migration, ordered by version.
+type Registry struct {
+	steps []*Step
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Add registers a step upgrading data from one version to a higher one.
+// There can only be one step from each version, and steps cannot go to a
+// lower or equal version. run must not be nil.
+func (r *Registry) Add(from, to *version.Version, run func() error) error {
+	if run == nil {
+		return fmt.Errorf("step %s -> %s has no function to run", from, to)
+	}
+	if !to.GreaterThan(from) {
+		return fmt.Errorf("step %s -> %s does not upgrade to a higher version", from, to)
+	}
+	for _, s := range r.steps {
+		if s.From.Equal(from) {
+			return fmt.Errorf("duplicate step from %s: %s and %s -> %s", from, s, from, to)
+		}
+	}
+
+	r.steps = append(r.steps, &Step{From: from, To: to, Run: run})
+	sort.SliceStable(r.steps, func(i, j int) bool {
+		return r.steps[i].From.LessThan(r.steps[j].From)
+	})
+
+	return nil
+}
+
+// Steps returns the registered steps, sorted by the version they upgrade
+// from.
+func (r *Registry) Steps() []*Step {
+	result := make([]*Step, len(r.steps))
+	copy(result, r.steps)
+
+	return result
+}
+
+// Plan returns the steps to run, in order, to upgrade data stored with the
+// given version to the target version. It returns a *GapError if the
+// steps do not lead from one to the other, and an error if the target is
+// lower than the stored version.
+func (r *Registry) Plan(stored, target *version.Version) ([]*Step, error) {
+	if target.LessThan(stored) {
+		return nil, fmt.Errorf("cannot migrate from %s to lower version %s", stored, target)
+	}
+
+	var plan []*Step
+	current := stored
+	for current.LessThan(target) {
+		step := r.stepFrom(current)
+		if step == nil || step.To.GreaterThan(target) {
+			return nil, &GapError{From: current, Target: target, Step: step}
+		}
+
+		plan = append(plan, step)
+		current = step.To
+	}
+
+	return plan, nil
+}
+
+// Run runs the steps upgrading data stored with the given version to the
+// target version, and returns the version the data was upgraded to. If a
+// step fails, the steps after it are not run, the error is a *StepError,
+// and the returned version is the one before that step.
+func (r *Registry) Run(stored, target *version.Version) (*version.Version, error) {
+	plan, err := r.Plan(stored, target)
+	if err != nil {
+		return stored, err
+	}
+
+	current := stored
+	for _, step := range plan {
+		if err := step.Run(); err != nil {
+			return current, &StepError{Step: step, Err: err}
+		}
+		current = step.To
+	}
+
+	return current, nil
+}
+
+func (r *Registry) stepFrom(v *version.Version) *Step {
+	i := sort.Search(len(r.steps), func(i int) bool {
+		return r.steps[i].From.GreaterThanOrEqual(v)
+	})
+	if i < len(r.steps) && r.steps[i].From.Equal(v) {
+		return r.steps[i]
+	}
+
+	return nil
+}
+
+// GapError is returned when no registered step continues the migration
+// from a version. Step is the step from that version, if there is one
+// that goes past the target.
+type GapError struct {
+	From   *version.Version
+	Target *version.Version
+	Step   *Step
+}
+
+func (e *GapError) Error() string {
+	if e.Step != nil {
+		return fmt.Sprintf("migration gap: step %s goes past target %s", e.Step, e.Target)
+	}
+
+	return fmt.Sprintf("migration gap: no step from %s towards %s", e.From, e.Target)
+}
+
+// StepError is returned when a step fails.
+type StepError struct {
+	Step *Step
+	Err  error
+}
+
+func (e *StepError) Error() string {
+	return fmt.Sprintf("step %s failed: %s", e.Step, e.Err)
+}
+
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,155 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package migrate
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	version "github.com/hashicorp/go-version"
+)
+
+func v(s string) *version.Version {
+	return version.Must(version.NewVersion(s))
+}
+
+// testRegistry returns a registry with steps 1.0 -> 1.1 -> 1.3 -> 2.0,
+// recording the steps that were run in log.
+func testRegistry(t *testing.T, log *[]string) *Registry {
+	t.Helper()
+
+	r := NewRegistry()
+	for _, s := range []string{"1.3 2.0", "1.0 1.1", "1.1 1.3", "2.1 2.2"} {
+		parts := strings.Fields(s)
+		name := s
+		if err := r.Add(v(parts[0]), v(parts[1]), func() error {
+			*log = append(*log, name)
+			return nil
+		}); err != nil {
+			t.Fatalf("error adding %s: %s", s, err)
+		}
+	}
+
+	return r
+}
+
+func TestRegistryAdd(t *testing.T) {
+	var log []string
+	r := testRegistry(t, &log)
+
+	var steps []string
+	for _, s := range r.Steps() {
+		steps = append(steps, s.String())
+	}
+	expected := []string{"1.0.0 -> 1.1.0", "1.1.0 -> 1.3.0", "1.3.0 -> 2.0.0", "2.1.0 -> 2.2.0"}
+	if !reflect.DeepEqual(steps, expected) {
+		t.Fatalf("expected: %#v\nactual: %#v", expected, steps)
+	}
+
+	cases := []struct {
+		from string
+		to   string
+		err  string
+	}{
+		{"1.1.0", "1.2", "duplicate step from 1.1.0: 1.1.0 -> 1.3.0 and 1.1.0 -> 1.2.0"},
+		{"1.5", "1.4", "step 1.5.0 -> 1.4.0 does not upgrade to a higher version"},
+		{"1.5", "1.5.0", "step 1.5.0 -> 1.5.0 does not upgrade to a higher version"},
+	}
+
+	for _, tc := range cases {
+		err := r.Add(v(tc.from), v(tc.to), func() error { return nil })
+		if err == nil || err.Error() != tc.err {
+			t.Fatalf("%s -> %s\nexpected: %s\nactual: %v", tc.from, tc.to, tc.err, err)
+		}
+	}
+
+	err := r.Add(v("2.2"), v("2.3"), nil)
+	if expected := "step 2.2.0 -> 2.3.0 has no function to run"; err == nil || err.Error() != expected {
+		t.Fatalf("expected: %s\nactual: %v", expected, err)
+	}
+}
+
+func TestRegistryPlan(t *testing.T) {
+	cases := []struct {
+		stored string
+		target string
+		steps  []string
+		err    string
+	}{
+		{"1.0", "2.0", []string{"1.0.0 -> 1.1.0", "1.1.0 -> 1.3.0", "1.3.0 -> 2.0.0"}, ""},
+		{"1.1", "1.3", []string{"1.1.0 -> 1.3.0"}, ""},
+		{"2.0", "2.0", nil, ""},
+		{"2.0", "2.2", nil, "migration gap: no step from 2.0.0 towards 2.2.0"},
+		{"1.2", "2.0", nil, "migration gap: no step from 1.2.0 towards 2.0.0"},
+		{"1.0", "1.2", nil, "migration gap: step 1.1.0 -> 1.3.0 goes past target 1.2.0"},
+		{"2.0", "1.0", nil, "cannot migrate from 2.0.0 to lower version 1.0.0"},
+	}
+
+	var log []string
+	r := testRegistry(t, &log)
+	for _, tc := range cases {
+		plan, err := r.Plan(v(tc.stored), v(tc.target))
+		if tc.err != "" {
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("%s to %s\nexpected: %s\nactual: %v", tc.stored, tc.target, tc.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s to %s: %s", tc.stored, tc.target, err)
+		}
+
+		var steps []string
+		for _, s := range plan {
+			steps = append(steps, s.String())
+		}
+		if !reflect.DeepEqual(steps, tc.steps) {
+			t.Fatalf("%s to %s\nexpected: %#v\nactual: %#v", tc.stored, tc.target, tc.steps, steps)
+		}
+	}
+}
+
+func TestRegistryRun(t *testing.T) {
+	var log []string
+	r := testRegistry(t, &log)
+
+	reached, err := r.Run(v("1.1"), v("2.0"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !reached.Equal(v("2.0")) {
+		t.Fatalf("bad version: %s", reached)
+	}
+	if expected := []string{"1.1 1.3", "1.3 2.0"}; !reflect.DeepEqual(log, expected) {
+		t.Fatalf("expected: %#v\nactual: %#v", expected, log)
+	}
+}
+
+func TestRegistryRun_failure(t *testing.T) {
+	failure := errors.New("disk full")
+
+	var log []string
+	r := testRegistry(t, &log)
+	if err := r.Add(v("2.0"), v("2.1"), func() error { return failure }); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	reached, err := r.Run(v("1.3"), v("2.2"))
+	if !reached.Equal(v("2.0")) {
+		t.Fatalf("bad version: %s", reached)
+	}
+
+	var stepErr *StepError
+	if !errors.As(err, &stepErr) || !errors.Is(err, failure) {
+		t.Fatalf("expected *StepError wrapping the failure, got %#v", err)
+	}
+	if err.Error() != "step 2.0.0 -> 2.1.0 failed: disk full" {
+		t.Fatalf("bad error: %s", err)
+	}
+	if expected := []string{"1.3 2.0"}; !reflect.DeepEqual(log, expected) {
+		t.Fatalf("expected: %#v\nactual: %#v", expected, log)
+	}
+}
@@ -0,0 +1,300 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+// Package mvs implements Minimal Version Selection over a graph of module
+// requirements, as used by Go modules: the build list holds, for each
+// module reachable from the target, the highest version required by any
+// module version in the graph.
+package mvs
+
+import (
+	"fmt"
+	"sort"
+
+	version "github.com/hashicorp/go-version"
+)
+
+// Module is a module path at a given version.
+type Module struct {
+	Path    string
+	Version *version.Version
+}
+
+func (m Module) String() string {
+	if m.Version == nil {
+		return m.Path
+	}
+
+	return m.Path + "@" + m.Version.String()
+}
+
+// Change describes how the version of a module in a build list changed.
+// To is nil if the module was removed from the build list.
+type Change struct {
+	Path string
+	From *version.Version
+	To   *version.Version
+}
+
+func (c Change) String() string {
+	if c.To == nil {
+		return fmt.Sprintf("%s@%s removed", c.Path, c.From)
+	}
+
+	return fmt.Sprintf("%s@%s => %s", c.Path, c.From, c.To)
+}
+
+// Graph is an in-memory module requirement graph, along with the
+// exclude and replace directives that apply to it.
+type Graph struct {
+	requirements map[string][]Module
+	versions     map[string]version.Collection
+	excluded     map[string]bool
+	replaced     map[string]Module
+}
+
+// NewGraph returns an empty Graph.
+func NewGraph() *Graph {
+	return &Graph{
+		requirements: make(map[string][]Module),
+		versions:     make(map[string]version.Collection),
+		excluded:     make(map[string]bool),
+		replaced:     make(map[string]Module),
+	}
+}
+
+// Add registers a module version and the module versions it requires.
+func (g *Graph) Add(m Module, requires ...Module) {
+	k := m.String()
+	if _, ok := g.requirements[k]; !ok {
+		g.versions[m.Path] = append(g.versions[m.Path], m.Version)
+		sort.Sort(g.versions[m.Path])
+	}
+
+	reqs := make([]Module, len(requires))
+	copy(reqs, requires)
+	g.requirements[k] = reqs
+}
+
+// Exclude excludes a module version, like the exclude directive of a
+// go.mod file. A requirement on an excluded version is treated as a
+// requirement on the next higher version in the graph that is not
+// excluded.
+func (g *Graph) Exclude(m Module) {
+	g.excluded[m.String()] = true
+}
+
+// Replace replaces the requirements of a module version with those of
+// another module version, like the replace directive of a go.mod file.
+// The build list still lists the original module. If the version of old is
+// nil, all of its versions are replaced.
+func (g *Graph) Replace(old, replacement Module) {
+	g.replaced[old.String()] = replacement
+}
+
+// BuildList returns the build list of the target module: the target
+// itself, followed by the selected version of every other module it
+// requires directly or indirectly, sorted by path.
+func (g *Graph) BuildList(target Module) ([]Module, error) {
+	reqs, err := g.required(target)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.buildList(target, reqs)
+}
+
+// Downgrade returns the build list of the target module after
+// downgrading one of its modules to the given version, along with the
+// changes that had to be made to the other modules of the build list.
+//
+// Every module whose selected version requires, directly or indirectly, a
+// version of m.Path higher than m.Version is downgraded to its highest
+// version that does not. A module without any such version is removed.
+func (g *Graph) Downgrade(target, m Module) ([]Module, []Change, error) {
+	if _, ok := g.requirements[m.String()]; !ok {
+		return nil, nil, fmt.Errorf("module %s not found", m)
+	}
+
+	list, err := g.BuildList(target)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// tooHigh walks the whole requirement graph of mod, so that a cycle
+	// never leaves a partial result behind
+	tooHigh := func(mod Module) (bool, error) {
+		visited := map[string]bool{mod.String(): true}
+		queue := []Module{mod}
+		for len(queue) > 0 {
+			r := queue[0]
+			queue = queue[1:]
+
+			if r.Path == m.Path {
+				if r.Version.GreaterThan(m.Version) {
+					return true, nil
+				}
+				continue
+			}
+
+			reqs, err := g.required(r)
+			if err != nil {
+				return false, err
+			}
+			for _, req := range reqs {
+				req, err := g.resolveExclude(req)
+				if err != nil {
+					return false, err
+				}
+				if !visited[req.String()] {
+					visited[req.String()] = true
+					queue = append(queue, req)
+				}
+			}
+		}
+
+		return false, nil
+	}
+
+	// The downgraded modules become the requirements of the target
+	reqs := []Module{m}
+	for _, mod := range list[1:] {
+		if mod.Path == m.Path {
+			continue
+		}
+
+		versions := g.versions[mod.Path]
+		for i := len(versions) - 1; i >= 0; i-- {
+			v := versions[i]
+			if v.GreaterThan(mod.Version) || g.excluded[Module{Path: mod.Path, Version: v}.String()] {
+				continue
+			}
+
+			high, err := tooHigh(Module{Path: mod.Path, Version: v})
+			if err != nil {
+				return nil, nil, err
+			}
+			if !high {
+				reqs = append(reqs, Module{Path: mod.Path, Version: v})
+				break
+			}
+		}
+	}
+
+	downgraded, err := g.buildList(target, reqs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	selected := make(map[string]*version.Version, len(downgraded))
+	for _, mod := range downgraded {
+		selected[mod.Path] = mod.Version
+	}
+
+	var changes []Change
+	for _, mod := range list[1:] {
+		if mod.Path == m.Path {
+			continue
+		}
+
+		to := selected[mod.Path]
+		if to == nil || !to.Equal(mod.Version) {
+			changes = append(changes, Change{Path: mod.Path, From: mod.Version, To: to})
+		}
+	}
+
+	return downgraded, changes, nil
+}
+
+// buildList returns the build list of the target, using the given
+// requirements for it.
+func (g *Graph) buildList(target Module, targetReqs []Module) ([]Module, error) {
+	selected := map[string]*version.Version{target.Path: target.Version}
+	visited := map[string]bool{target.String(): true}
+
+	var queue []Module
+	enqueue := func(reqs []Module) error {
+		for _, r := range reqs {
+			r, err := g.resolveExclude(r)
+			if err != nil {
+				return err
+			}
+
+			// The target is always selected at its own version
+			if r.Path == target.Path {
+				continue
+			}
+			if v, ok := selected[r.Path]; !ok || r.Version.GreaterThan(v) {
+				selected[r.Path] = r.Version
+			}
+			if !visited[r.String()] {
+				visited[r.String()] = true
+				queue = append(queue, r)
+			}
+		}
+
+		return nil
+	}
+
+	if err := enqueue(targetReqs); err != nil {
+		return nil, err
+	}
+	for len(queue) > 0 {
+		m := queue[0]
+		queue = queue[1:]
+
+		reqs, err := g.required(m)
+		if err != nil {
+			return nil, err
+		}
+		if err := enqueue(reqs); err != nil {
+			return nil, err
+		}
+	}
+
+	list := make([]Module, 0, len(selected))
+	for path, v := range selected {
+		if path != target.Path {
+			list = append(list, Module{Path: path, Version: v})
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Path < list[j].Path
+	})
+
+	return append([]Module{target}, list...), nil
+}
+
+// required returns the requirements of a module version, taking
+// replacements into account.
+func (g *Graph) required(m Module) ([]Module, error) {
+	if r, ok := g.replaced[m.String()]; ok {
+		m = r
+	} else if r, ok := g.replaced[m.Path]; ok {
+		m = r
+	}
+
+	reqs, ok := g.requirements[m.String()]
+	if !ok {
+		return nil, fmt.Errorf("module %s not found", m)
+	}
+
+	return reqs, nil
+}
+
+// resolveExclude returns the module version to use for a requirement on
+// m, which is the lowest version at least as high that is not excluded.
+func (g *Graph) resolveExclude(m Module) (Module, error) {
+	if !g.excluded[m.String()] {
+		return m, nil
+	}
+
+	for _, v := range g.versions[m.Path] {
+		candidate := Module{Path: m.Path, Version: v}
+		if v.GreaterThan(m.Version) && !g.excluded[candidate.String()] {
+			return candidate, nil
+		}
+	}
+
+	return Module{}, fmt.Errorf("module %s is excluded and no higher version is available", m)
+}
@@ -0,0 +1,220 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package mvs
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	version "github.com/hashicorp/go-version"
+)
+
+func mod(s string) Module {
+	parts := strings.SplitN(s, "@", 2)
+	if len(parts) == 1 {
+		return Module{Path: parts[0]}
+	}
+
+	return Module{Path: parts[0], Version: version.Must(version.NewVersion(parts[1]))}
+}
+
+// testGraph builds the example graph from the Minimal Version Selection
+// design: A 1 requires B 1.2 and C 1.2, which both require some D 1.x.
+func testGraph(t *testing.T, extra string) *Graph {
+	t.Helper()
+
+	g := NewGraph()
+	spec := `
+		a@1.0: b@1.2 c@1.2
+		b@1.1: d@1.1
+		b@1.2: d@1.3
+		b@1.3: d@1.4
+		c@1.1:
+		c@1.2: d@1.4
+		c@1.3: d@1.5 f@1.1
+		d@1.1: e@1.1
+		d@1.2: e@1.1
+		d@1.3: e@1.2
+		d@1.4: e@1.2
+		d@1.5: e@1.3
+		e@1.1:
+		e@1.2:
+		e@1.3:
+		f@1.1: g@1.1
+		g@1.1: f@1.1
+	` + extra
+
+	for _, line := range strings.Split(spec, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 2)
+		var reqs []Module
+		for _, r := range strings.Fields(parts[1]) {
+			reqs = append(reqs, mod(r))
+		}
+		g.Add(mod(parts[0]), reqs...)
+	}
+
+	return g
+}
+
+func TestGraphBuildList(t *testing.T) {
+	cases := []struct {
+		name     string
+		extra    string
+		setup    func(*Graph)
+		target   string
+		expected string
+	}{
+		{
+			"simple",
+			"",
+			nil,
+			"a@1.0",
+			"[a@1.0.0 b@1.2.0 c@1.2.0 d@1.4.0 e@1.2.0]",
+		},
+		{
+			"cycle",
+			"",
+			nil,
+			"c@1.3",
+			"[c@1.3.0 d@1.5.0 e@1.3.0 f@1.1.0 g@1.1.0]",
+		},
+		{
+			"exclude",
+			"",
+			func(g *Graph) { g.Exclude(mod("d@1.4")) },
+			"a@1.0",
+			"[a@1.0.0 b@1.2.0 c@1.2.0 d@1.5.0 e@1.3.0]",
+		},
+		{
+			"replace version",
+			"x@1.0: e@1.3",
+			func(g *Graph) { g.Replace(mod("c@1.2"), mod("x@1.0")) },
+			"a@1.0",
+			"[a@1.0.0 b@1.2.0 c@1.2.0 d@1.3.0 e@1.3.0]",
+		},
+		{
+			"replace all versions",
+			"x@1.0:",
+			func(g *Graph) { g.Replace(mod("d"), mod("x@1.0")) },
+			"a@1.0",
+			"[a@1.0.0 b@1.2.0 c@1.2.0 d@1.4.0]",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := testGraph(t, tc.extra)
+			if tc.setup != nil {
+				tc.setup(g)
+			}
+
+			list, err := g.BuildList(mod(tc.target))
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			actual := fmt.Sprint(list)
+			if actual != tc.expected {
+				t.Fatalf("expected: %s\nactual: %s", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestGraphBuildList_errors(t *testing.T) {
+	g := testGraph(t, "z@1.0: y@1.0")
+	if _, err := g.BuildList(mod("z@1.0")); err == nil {
+		t.Fatalf("expected error for a missing module")
+	}
+
+	g = testGraph(t, "")
+	g.Exclude(mod("e@1.3"))
+	if _, err := g.BuildList(mod("d@1.5")); err == nil {
+		t.Fatalf("expected error for an excluded module without a higher version")
+	}
+}
+
+func TestGraphDowngrade(t *testing.T) {
+	cases := []struct {
+		name      string
+		extra     string
+		target    string
+		downgrade string
+		expected  string
+		changes   string
+	}{
+		{
+			"dependents downgraded",
+			"",
+			"a@1.0",
+			"d@1.3",
+			"[a@1.0.0 b@1.2.0 c@1.1.0 d@1.3.0 e@1.2.0]",
+			"[c@1.2.0 => 1.1.0]",
+		},
+		{
+			"unrelated modules kept",
+			"",
+			"a@1.0",
+			"d@1.1",
+			"[a@1.0.0 b@1.1.0 c@1.1.0 d@1.1.0 e@1.2.0]",
+			"[b@1.2.0 => 1.1.0 c@1.2.0 => 1.1.0]",
+		},
+		{
+			"indirect dependents downgraded",
+			"",
+			"a@1.0",
+			"e@1.1",
+			"[a@1.0.0 b@1.1.0 c@1.1.0 d@1.2.0 e@1.1.0]",
+			"[b@1.2.0 => 1.1.0 c@1.2.0 => 1.1.0 d@1.4.0 => 1.2.0]",
+		},
+		{
+			"dependency of target downgraded",
+			"",
+			"c@1.3",
+			"e@1.2",
+			"[c@1.3.0 d@1.4.0 e@1.2.0 f@1.1.0 g@1.1.0]",
+			"[d@1.5.0 => 1.4.0]",
+		},
+		{
+			"module removed",
+			"h@1.0: i@1.0 e@1.1\ni@1.0: e@1.3",
+			"h@1.0",
+			"e@1.2",
+			"[h@1.0.0 e@1.2.0]",
+			"[i@1.0.0 removed]",
+		},
+		{
+			"cycle downgraded",
+			"t@1.0: p@1.0 q@1.0\np@0.9:\np@1.0: q@1.0 r@2.0\nq@0.9:\nq@1.0: p@1.0\nr@1.0:\nr@2.0:",
+			"t@1.0",
+			"r@1.0",
+			"[t@1.0.0 p@0.9.0 q@0.9.0 r@1.0.0]",
+			"[p@1.0.0 => 0.9.0 q@1.0.0 => 0.9.0]",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := testGraph(t, tc.extra)
+
+			list, changes, err := g.Downgrade(mod(tc.target), mod(tc.downgrade))
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			if actual := fmt.Sprint(list); actual != tc.expected {
+				t.Fatalf("expected: %s\nactual: %s", tc.expected, actual)
+			}
+			if actual := fmt.Sprint(changes); actual != tc.changes {
+				t.Fatalf("expected changes: %s\nactual: %s", tc.changes, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+// Package negotiate finds the version, such as a protocol version, that
+// two peers should use to talk to each other.
+package negotiate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	version "github.com/hashicorp/go-version"
+)
+
+// Option changes how the negotiated version is chosen.
+type Option func(*options)
+
+type options struct {
+	sameMajor *version.Version
+}
+
+// PreferSameMajor is an Option that chooses the highest shared version
+// with the same major version as current, if there is one, over any newer
+// shared version. This avoids a breaking protocol change between peers
+// that can keep talking as they do.
+func PreferSameMajor(current *version.Version) Option {
+	return func(o *options) {
+		o.sameMajor = current
+	}
+}
+
+// MismatchError is returned when the peers do not share any version. Local
+// and Remote are the versions supported by each peer, sorted from lowest
+// to highest.
+type MismatchError struct {
+	Local  version.Collection
+	Remote version.Collection
+}
+
+func (e *MismatchError) Error() string {
+	msg := fmt.Sprintf(
+		"no mutually supported version: local supports %s, remote supports %s",
+		collectionString(e.Local), collectionString(e.Remote))
+
+	switch {
+	case len(e.Local) == 0 || len(e.Remote) == 0:
+	case e.Local[len(e.Local)-1].LessThan(e.Remote[0]):
+		msg += "; local is older than remote"
+	case e.Remote[len(e.Remote)-1].LessThan(e.Local[0]):
+		msg += "; remote is older than local"
+	}
+
+	return msg
+}
+
+// Versions returns the highest version supported by both peers, given as
+// the list of versions each one supports. If there is none, the error is
+// a *MismatchError.
+func Versions(local, remote version.Collection, opts ...Option) (*version.Version, error) {
+	o := &options{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+
+	var shared version.Collection
+	for _, l := range local {
+		for _, r := range remote {
+			if l.Equal(r) {
+				shared = append(shared, l)
+				break
+			}
+		}
+	}
+	if len(shared) == 0 {
+		return nil, &MismatchError{Local: sorted(local), Remote: sorted(remote)}
+	}
+	sort.Stable(shared)
+
+	if o.sameMajor != nil {
+		major := o.sameMajor.Segments64()[0]
+		for i := len(shared) - 1; i >= 0; i-- {
+			if shared[i].Segments64()[0] == major {
+				return shared[i], nil
+			}
+		}
+	}
+
+	return shared[len(shared)-1], nil
+}
+
+// Constraints is like Versions for peers that give the versions they
+// support as constraints. The negotiated version is one of the known
+// versions, which both constraints are checked against.
+func Constraints(local, remote version.Constraints, known version.Collection, opts ...Option) (*version.Version, error) {
+	var localVersions, remoteVersions version.Collection
+	for _, v := range known {
+		if local.Check(v) {
+			localVersions = append(localVersions, v)
+		}
+		if remote.Check(v) {
+			remoteVersions = append(remoteVersions, v)
+		}
+	}
+
+	return Versions(localVersions, remoteVersions, opts...)
+}
+
+func sorted(versions version.Collection) version.Collection {
+	result := make(version.Collection, len(versions))
+	copy(result, versions)
+	sort.Stable(result)
+
+	return result
+}
+
+func collectionString(versions version.Collection) string {
+	if len(versions) == 0 {
+		return "no versions"
+	}
+
+	strs := make([]string, len(versions))
+	for i, v := range versions {
+		strs[i] = v.String()
+	}
+
+	return strings.Join(strs, ", ")
+}
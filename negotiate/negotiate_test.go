@@ -0,0 +1,115 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package negotiate
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	version "github.com/hashicorp/go-version"
+)
+
+func collection(s string) version.Collection {
+	var result version.Collection
+	for _, f := range strings.Fields(s) {
+		result = append(result, version.Must(version.NewVersion(f)))
+	}
+
+	return result
+}
+
+func TestVersions(t *testing.T) {
+	cases := []struct {
+		local    string
+		remote   string
+		opts     []Option
+		expected string
+	}{
+		{"1.0 1.1 2.0", "1.1 2.0 3.0", nil, "2.0.0"},
+		{"3 1 2", "2 1", nil, "2.0.0"},
+		{"1.0 1.1 2.0", "1.0 1.1 2.0", []Option{PreferSameMajor(version.Must(version.NewVersion("1.0")))}, "1.1.0"},
+		{"1.0 2.0", "2.0", []Option{PreferSameMajor(version.Must(version.NewVersion("1.0")))}, "2.0.0"},
+		{"5 6", "5.0.0 6.0.0-beta", nil, "5.0.0"},
+	}
+
+	for _, tc := range cases {
+		actual, err := Versions(collection(tc.local), collection(tc.remote), tc.opts...)
+		if err != nil {
+			t.Fatalf("%s / %s: %s", tc.local, tc.remote, err)
+		}
+		if actual.String() != tc.expected {
+			t.Fatalf("%s / %s: expected %s, got %s", tc.local, tc.remote, tc.expected, actual)
+		}
+	}
+}
+
+func TestVersions_mismatch(t *testing.T) {
+	cases := []struct {
+		local  string
+		remote string
+		err    string
+	}{
+		{
+			"1.1 1.0", "2.0 3.0",
+			"no mutually supported version: local supports 1.0.0, 1.1.0, remote supports 2.0.0, 3.0.0; local is older than remote",
+		},
+		{
+			"4.0", "1.0 2.0",
+			"no mutually supported version: local supports 4.0.0, remote supports 1.0.0, 2.0.0; remote is older than local",
+		},
+		{
+			"1.0 3.0", "2.0",
+			"no mutually supported version: local supports 1.0.0, 3.0.0, remote supports 2.0.0",
+		},
+		{
+			"", "2.0",
+			"no mutually supported version: local supports no versions, remote supports 2.0.0",
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := Versions(collection(tc.local), collection(tc.remote))
+		var mismatch *MismatchError
+		if !errors.As(err, &mismatch) {
+			t.Fatalf("%s / %s: expected *MismatchError, got %#v", tc.local, tc.remote, err)
+		}
+		if err.Error() != tc.err {
+			t.Fatalf("%s / %s\nexpected: %s\nactual: %s", tc.local, tc.remote, tc.err, err)
+		}
+	}
+}
+
+func TestConstraints(t *testing.T) {
+	known := collection("1.0 1.1 1.2 2.0 2.1")
+
+	cases := []struct {
+		local    string
+		remote   string
+		opts     []Option
+		expected string
+		err      bool
+	}{
+		{">= 1.0", "< 2.1", nil, "2.0.0", false},
+		{"~> 1.0", ">= 1.1", nil, "1.2.0", false},
+		{">= 1.1", ">= 1.0", []Option{PreferSameMajor(version.Must(version.NewVersion("1.1")))}, "1.2.0", false},
+		{"< 1.1", ">= 2.0", nil, "", true},
+	}
+
+	for _, tc := range cases {
+		local := version.MustConstraints(version.NewConstraint(tc.local))
+		remote := version.MustConstraints(version.NewConstraint(tc.remote))
+
+		actual, err := Constraints(local, remote, known, tc.opts...)
+		if (err != nil) != tc.err {
+			t.Fatalf("%s / %s: unexpected error: %v", tc.local, tc.remote, err)
+		}
+		if err != nil {
+			continue
+		}
+		if actual.String() != tc.expected {
+			t.Fatalf("%s / %s: expected %s, got %s", tc.local, tc.remote, tc.expected, actual)
+		}
+	}
+}
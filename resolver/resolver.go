@@ -0,0 +1,322 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+// Package resolver selects a version for each package of a dependency
+// graph, such that every version constraint in the graph is satisfied.
+package resolver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	version "github.com/hashicorp/go-version"
+)
+
+// Release is a single version of a package, along with the constraints it
+// places on the versions of the packages it depends on.
+type Release struct {
+	Version      *version.Version
+	Dependencies map[string]version.Constraints
+}
+
+// Registry is an in-memory index of the releases available for each
+// package.
+type Registry struct {
+	packages map[string][]*Release
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{packages: make(map[string][]*Release)}
+}
+
+// Add registers a release of the named package. Adding a version that is
+// already registered replaces its dependencies.
+func (r *Registry) Add(name string, v *version.Version, deps map[string]version.Constraints) {
+	release := &Release{Version: v, Dependencies: deps}
+	releases := r.packages[name]
+	for i, existing := range releases {
+		if existing.Version.Equal(v) {
+			releases[i] = release
+			return
+		}
+	}
+
+	r.packages[name] = append(releases, release)
+}
+
+// Versions returns the registered versions of the named package, sorted
+// from oldest to newest.
+func (r *Registry) Versions(name string) version.Collection {
+	releases := r.releases(name)
+	result := make(version.Collection, len(releases))
+	for i, release := range releases {
+		result[i] = release.Version
+	}
+
+	return result
+}
+
+// Release returns the release of the named package with the given
+// version, or nil if there is none.
+func (r *Registry) Release(name string, v *version.Version) *Release {
+	for _, release := range r.packages[name] {
+		if release.Version.Equal(v) {
+			return release
+		}
+	}
+
+	return nil
+}
+
+// releases returns the releases of the named package sorted by version.
+func (r *Registry) releases(name string) []*Release {
+	releases := make([]*Release, len(r.packages[name]))
+	copy(releases, r.packages[name])
+	sort.SliceStable(releases, func(i, j int) bool {
+		return releases[i].Version.LessThan(releases[j].Version)
+	})
+
+	return releases
+}
+
+// Selection maps each package name to the version selected for it.
+type Selection map[string]*version.Version
+
+// Requirement is a constraint placed on a package, either by a release of
+// another package or, when Package is empty, by the caller of Resolve.
+type Requirement struct {
+	Package     string
+	Version     *version.Version
+	Constraints version.Constraints
+}
+
+// ConflictError is returned by Resolve when no selection satisfies all the
+// constraints. It describes one set of requirements on a package that no
+// available version of that package satisfies together.
+type ConflictError struct {
+	Package      string
+	Requirements []Requirement
+	Available    version.Collection
+}
+
+func (e *ConflictError) Error() string {
+	var b strings.Builder
+	if len(e.Available) == 0 {
+		fmt.Fprintf(&b, "no versions of %s are available", e.Package)
+	} else {
+		fmt.Fprintf(&b, "no version of %s satisfies all requirements", e.Package)
+	}
+
+	for _, r := range e.Requirements {
+		if r.Package == "" {
+			fmt.Fprintf(&b, "\n  %s %s is required", e.Package, r.Constraints)
+		} else {
+			fmt.Fprintf(&b, "\n  %s %s requires %s %s", r.Package, r.Version, e.Package, r.Constraints)
+		}
+	}
+	if len(e.Available) > 0 {
+		strs := make([]string, len(e.Available))
+		for i, v := range e.Available {
+			strs[i] = v.String()
+		}
+		fmt.Fprintf(&b, "\n  available versions: %s", strings.Join(strs, ", "))
+	}
+
+	return b.String()
+}
+
+// Resolve selects a version for each package required, directly or
+// through dependencies, by the given constraints.
+//
+// Newer versions are preferred: packages are resolved one at a time,
+// trying their newest acceptable release first and backtracking when a
+// choice leads to a conflict. The package with the fewest acceptable
+// releases is resolved first, which keeps backtracking short.
+//
+// If there is no solution, the error is a *ConflictError describing the
+// conflict found closest to the given requirements.
+func Resolve(registry *Registry, requirements map[string]version.Constraints) (Selection, error) {
+	s := &solver{
+		registry:     registry,
+		selected:     make(map[string]*Release),
+		requirements: make(map[string][]Requirement),
+	}
+
+	for _, name := range sortedNames(requirements) {
+		s.requirements[name] = append(s.requirements[name], Requirement{
+			Constraints: requirements[name],
+		})
+		if len(s.candidates(name)) == 0 {
+			return nil, s.conflictError(name)
+		}
+	}
+
+	if !s.solve() {
+		if s.conflict != nil {
+			return nil, s.conflict
+		}
+		return nil, fmt.Errorf("no selection satisfies the requirements")
+	}
+
+	result := make(Selection, len(s.selected))
+	for name, release := range s.selected {
+		result[name] = release.Version
+	}
+
+	return result, nil
+}
+
+type solver struct {
+	registry     *Registry
+	selected     map[string]*Release
+	requirements map[string][]Requirement
+
+	// conflict is the conflict found with the fewest selected packages,
+	// which is the one closest to the requirements given to Resolve.
+	conflict      *ConflictError
+	conflictDepth int
+}
+
+func (s *solver) solve() bool {
+	name, candidates, ok := s.next()
+	if !ok {
+		return true
+	}
+
+	for i := len(candidates) - 1; i >= 0; i-- {
+		release := candidates[i]
+		added, ok := s.selectRelease(name, release)
+		if ok && s.solve() {
+			return true
+		}
+		s.deselect(name, added)
+	}
+
+	return false
+}
+
+// next returns the unselected required package with the fewest candidate
+// releases, along with those candidates sorted from oldest to newest.
+func (s *solver) next() (string, []*Release, bool) {
+	var best string
+	var bestCandidates []*Release
+	found := false
+	names := make([]string, 0, len(s.requirements))
+	for name := range s.requirements {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if _, ok := s.selected[name]; ok || len(s.requirements[name]) == 0 {
+			continue
+		}
+
+		candidates := s.candidates(name)
+		if !found || len(candidates) < len(bestCandidates) {
+			best, bestCandidates, found = name, candidates, true
+		}
+	}
+
+	return best, bestCandidates, found
+}
+
+// candidates returns the releases of the named package that satisfy all
+// its current requirements, sorted from oldest to newest.
+func (s *solver) candidates(name string) []*Release {
+	var result []*Release
+	for _, release := range s.registry.releases(name) {
+		if s.satisfies(name, release.Version) {
+			result = append(result, release)
+		}
+	}
+
+	return result
+}
+
+func (s *solver) satisfies(name string, v *version.Version) bool {
+	for _, r := range s.requirements[name] {
+		if !r.Constraints.Check(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// selectRelease selects the release and adds the requirements of its
+// dependencies. It returns the names of the packages that requirements
+// were added to, and false if a dependency can no longer be satisfied.
+func (s *solver) selectRelease(name string, release *Release) ([]string, bool) {
+	s.selected[name] = release
+
+	var added []string
+	for _, dep := range sortedNames(release.Dependencies) {
+		s.requirements[dep] = append(s.requirements[dep], Requirement{
+			Package:     name,
+			Version:     release.Version,
+			Constraints: release.Dependencies[dep],
+		})
+		added = append(added, dep)
+
+		if selected, ok := s.selected[dep]; ok {
+			if !s.satisfies(dep, selected.Version) {
+				s.recordConflict(dep)
+				return added, false
+			}
+		} else if len(s.candidates(dep)) == 0 {
+			s.recordConflict(dep)
+			return added, false
+		}
+	}
+
+	return added, true
+}
+
+// deselect reverts a call to selectRelease.
+func (s *solver) deselect(name string, added []string) {
+	delete(s.selected, name)
+	for _, dep := range added {
+		reqs := s.requirements[dep]
+		s.requirements[dep] = reqs[:len(reqs)-1]
+	}
+}
+
+// recordConflict remembers the requirements on the named package if no
+// available version satisfies all of them, and if this happened closer to
+// the initial requirements than any conflict recorded before.
+func (s *solver) recordConflict(name string) {
+	if len(s.candidates(name)) > 0 {
+		// Another version of an already selected package would do, so this
+		// is not a conflict by itself.
+		return
+	}
+
+	if s.conflict == nil || len(s.selected) < s.conflictDepth {
+		s.conflict = s.conflictError(name)
+		s.conflictDepth = len(s.selected)
+	}
+}
+
+func (s *solver) conflictError(name string) *ConflictError {
+	reqs := make([]Requirement, len(s.requirements[name]))
+	copy(reqs, s.requirements[name])
+
+	return &ConflictError{
+		Package:      name,
+		Requirements: reqs,
+		Available:    s.registry.Versions(name),
+	}
+}
+
+func sortedNames(m map[string]version.Constraints) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
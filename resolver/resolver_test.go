@@ -0,0 +1,211 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package resolver
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	version "github.com/hashicorp/go-version"
+)
+
+// testRegistry builds a registry from "name version" keys mapped to
+// dependency constraint strings.
+func testRegistry(t *testing.T, releases map[string]map[string]string) *Registry {
+	t.Helper()
+
+	r := NewRegistry()
+	for key, deps := range releases {
+		var name, raw string
+		if _, err := fmt.Sscan(key, &name, &raw); err != nil {
+			t.Fatalf("bad release %q: %s", key, err)
+		}
+
+		constraints := make(map[string]version.Constraints, len(deps))
+		for dep, c := range deps {
+			constraints[dep] = version.MustConstraints(version.NewConstraint(c))
+		}
+		r.Add(name, version.Must(version.NewVersion(raw)), constraints)
+	}
+
+	return r
+}
+
+func selectionStrings(s Selection) []string {
+	result := make([]string, 0, len(s))
+	for name, v := range s {
+		result = append(result, name+" "+v.String())
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+func TestResolve(t *testing.T) {
+	cases := []struct {
+		name         string
+		releases     map[string]map[string]string
+		requirements map[string]string
+		expected     []string
+	}{
+		{
+			"newest",
+			map[string]map[string]string{
+				"a 1.0.0": nil,
+				"a 1.1.0": nil,
+				"a 2.0.0": nil,
+			},
+			map[string]string{"a": "~> 1.0"},
+			[]string{"a 1.1.0"},
+		},
+		{
+			"transitive",
+			map[string]map[string]string{
+				"a 1.0.0": {"b": ">= 1.0"},
+				"b 1.0.0": {"c": "~> 1.2"},
+				"b 1.5.0": {"c": "~> 1.2"},
+				"c 1.2.0": nil,
+				"c 1.3.0": nil,
+				"c 2.0.0": nil,
+			},
+			map[string]string{"a": ">= 1.0"},
+			[]string{"a 1.0.0", "b 1.5.0", "c 1.3.0"},
+		},
+		{
+			"backtracking",
+			map[string]map[string]string{
+				"a 1.0.0": {"c": "< 2.0"},
+				"a 2.0.0": {"c": ">= 2.0"},
+				"b 1.0.0": {"c": "< 2.0"},
+				"c 1.0.0": nil,
+				"c 2.0.0": nil,
+			},
+			map[string]string{"a": ">= 1.0", "b": ">= 1.0"},
+			[]string{"a 1.0.0", "b 1.0.0", "c 1.0.0"},
+		},
+		{
+			"backtracking selected dependency",
+			map[string]map[string]string{
+				"a 1.0.0": {"b": ">= 1.0", "c": ">= 1.0"},
+				"b 1.0.0": {"d": "1.0"},
+				"c 1.0.0": {"d": ">= 1.0"},
+				"c 2.0.0": {"d": "2.0"},
+				"d 1.0.0": nil,
+				"d 2.0.0": nil,
+			},
+			map[string]string{"a": "1.0"},
+			[]string{"a 1.0.0", "b 1.0.0", "c 1.0.0", "d 1.0.0"},
+		},
+		{
+			"nothing required",
+			map[string]map[string]string{"a 1.0.0": nil},
+			map[string]string{},
+			[]string{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			registry := testRegistry(t, tc.releases)
+			requirements := make(map[string]version.Constraints)
+			for name, c := range tc.requirements {
+				requirements[name] = version.MustConstraints(version.NewConstraint(c))
+			}
+
+			selection, err := Resolve(registry, requirements)
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			actual := selectionStrings(selection)
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("expected: %q\nactual: %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestResolve_conflict(t *testing.T) {
+	cases := []struct {
+		name         string
+		releases     map[string]map[string]string
+		requirements map[string]string
+		expected     string
+	}{
+		{
+			"incompatible dependencies",
+			map[string]map[string]string{
+				"a 1.0.0": {"c": ">= 2.0"},
+				"b 1.0.0": {"c": "< 2.0"},
+				"c 1.0.0": nil,
+				"c 2.0.0": nil,
+			},
+			map[string]string{"a": ">= 1.0", "b": ">= 1.0"},
+			"no version of c satisfies all requirements\n" +
+				"  a 1.0.0 requires c >= 2.0\n" +
+				"  b 1.0.0 requires c < 2.0\n" +
+				"  available versions: 1.0.0, 2.0.0",
+		},
+		{
+			"unsatisfiable requirement",
+			map[string]map[string]string{
+				"a 1.0.0": nil,
+				"a 1.1.0": nil,
+			},
+			map[string]string{"a": "~> 2.0"},
+			"no version of a satisfies all requirements\n" +
+				"  a ~> 2.0 is required\n" +
+				"  available versions: 1.0.0, 1.1.0",
+		},
+		{
+			"missing package",
+			map[string]map[string]string{
+				"a 1.0.0": {"b": ">= 1.0"},
+			},
+			map[string]string{"a": ">= 1.0"},
+			"no versions of b are available\n" +
+				"  a 1.0.0 requires b >= 1.0",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			registry := testRegistry(t, tc.releases)
+			requirements := make(map[string]version.Constraints)
+			for name, c := range tc.requirements {
+				requirements[name] = version.MustConstraints(version.NewConstraint(c))
+			}
+
+			_, err := Resolve(registry, requirements)
+			if _, ok := err.(*ConflictError); !ok {
+				t.Fatalf("expected a *ConflictError, got: %#v", err)
+			}
+			if err.Error() != tc.expected {
+				t.Fatalf("expected:\n%s\nactual:\n%s", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestRegistryVersions(t *testing.T) {
+	r := NewRegistry()
+	for _, raw := range []string{"1.2.0", "1.0.0", "2.0.0", "1.0"} {
+		r.Add("a", version.Must(version.NewVersion(raw)), nil)
+	}
+
+	actual := fmt.Sprint(r.Versions("a"))
+	expected := "[1.0.0 1.2.0 2.0.0]"
+	if actual != expected {
+		t.Fatalf("expected: %s\nactual: %s", expected, actual)
+	}
+
+	if r.Release("a", version.Must(version.NewVersion("1.2"))) == nil {
+		t.Fatalf("expected to find release 1.2")
+	}
+	if r.Release("a", version.Must(version.NewVersion("1.3"))) != nil {
+		t.Fatalf("expected no release 1.3")
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+// Package support evaluates support-window policies, such as "the latest
+// three minor lines plus the 1.15 LTS line", against a list of releases.
+package support
+
+import (
+	"fmt"
+	"sort"
+
+	version "github.com/hashicorp/go-version"
+)
+
+// Status is the support status of a version under a Policy.
+type Status int
+
+const (
+	EOL Status = iota
+	Deprecated
+	Supported
+)
+
+func (s Status) String() string {
+	switch s {
+	case Supported:
+		return "supported"
+	case Deprecated:
+		return "deprecated"
+	default:
+		return "eol"
+	}
+}
+
+// Policy describes which minor lines of a product are supported. A minor
+// line is every version sharing the same major and minor version, such as
+// 1.15.x.
+//
+// Versions newer than every release are treated as part of the latest
+// minor line, so an upcoming release is supported before it is published.
+type Policy struct {
+	// Minors is the number of latest minor lines that are supported.
+	Minors int
+
+	// DeprecatedMinors is the number of minor lines, older than the
+	// supported ones, that are deprecated rather than EOL.
+	DeprecatedMinors int
+
+	// LTS lists the long-term support lines, which are always supported.
+	// Only the major and minor version of each are used.
+	LTS []*version.Version
+}
+
+// Status returns the support status of v, given the releases published so
+// far. Pre-releases are ignored when finding the minor lines, and v has
+// the status of the line of its major and minor segments, so that
+// 1.16.0-rc.1 has the status of 1.16.x. A line older than the latest one
+// without any release is EOL.
+func (p *Policy) Status(releases version.Collection, v *version.Version) Status {
+	segments := v.Segments64()
+	line := [2]int64{segments[0], segments[1]}
+
+	for _, lts := range p.LTS {
+		if ltsSegments := lts.Segments64(); ltsSegments[0] == line[0] && ltsSegments[1] == line[1] {
+			return Supported
+		}
+	}
+
+	lines := minorLines(releases)
+	if len(lines) > 0 && p.Minors > 0 && lineLess(lines[0], line) {
+		// Upcoming lines are part of the latest one
+		return Supported
+	}
+
+	for i, l := range lines {
+		if l != line {
+			continue
+		}
+
+		switch {
+		case i < p.Minors:
+			return Supported
+		case i < p.Minors+p.DeprecatedMinors:
+			return Deprecated
+		}
+	}
+
+	return EOL
+}
+
+// Constraint returns the constraint string describing the versions that
+// are supported, given the releases published so far, such as
+// ">= 1.15.0, < 1.16.0 || >= 1.18.0".
+//
+// Unlike Status, the constraint does not match pre-releases: 1.18.0-rc.1
+// has the status of the supported 1.18.x line, but does not satisfy
+// ">= 1.18.0". A version without a pre-release satisfies the constraint
+// exactly when its status is Supported.
+func (p *Policy) Constraint(releases version.Collection) string {
+	var supported []version.Constraints
+	for i, l := range minorLines(releases) {
+		if i >= p.Minors {
+			break
+		}
+
+		cs := lineConstraints(l)
+		if i == 0 {
+			// Keep the latest line open for upcoming releases
+			cs = cs[:1]
+		}
+		supported = append(supported, cs)
+	}
+	for _, v := range p.LTS {
+		segments := v.Segments64()
+		supported = append(supported, lineConstraints([2]int64{segments[0], segments[1]}))
+	}
+
+	return version.ConstraintsUnion(supported).Union().String()
+}
+
+// minorLines returns the major and minor version of every minor line
+// with a release, latest first.
+func minorLines(releases version.Collection) [][2]int64 {
+	seen := make(map[[2]int64]bool)
+	var lines [][2]int64
+	for _, v := range releases {
+		if v.Prerelease() != "" {
+			continue
+		}
+
+		segments := v.Segments64()
+		l := [2]int64{segments[0], segments[1]}
+		if !seen[l] {
+			seen[l] = true
+			lines = append(lines, l)
+		}
+	}
+
+	sort.Slice(lines, func(i, j int) bool {
+		return lineLess(lines[j], lines[i])
+	})
+
+	return lines
+}
+
+// lineConstraints returns the constraints matching a minor line, with the
+// lower bound first.
+func lineConstraints(l [2]int64) version.Constraints {
+	return version.MustConstraints(version.NewConstraint(
+		fmt.Sprintf(">= %d.%d.0, < %d.%d.0", l[0], l[1], l[0], l[1]+1)))
+}
+
+// lineLess tests if minor line a is older than minor line b.
+func lineLess(a, b [2]int64) bool {
+	if a[0] != b[0] {
+		return a[0] < b[0]
+	}
+
+	return a[1] < b[1]
+}
@@ -0,0 +1,147 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package support
+
+import (
+	"testing"
+
+	version "github.com/hashicorp/go-version"
+)
+
+func testReleases(t *testing.T) version.Collection {
+	t.Helper()
+
+	raw := []string{
+		"1.14.0", "1.15.0", "1.15.3", "1.16.0", "1.16.1",
+		"1.17.0", "1.18.0", "1.18.2", "1.19.0", "1.20.0", "1.21.0-beta1",
+	}
+
+	result := make(version.Collection, len(raw))
+	for i, s := range raw {
+		result[i] = version.Must(version.NewVersion(s))
+	}
+
+	return result
+}
+
+func TestPolicyStatus(t *testing.T) {
+	p := &Policy{
+		Minors:           3,
+		DeprecatedMinors: 1,
+		LTS:              []*version.Version{version.Must(version.NewVersion("1.15"))},
+	}
+
+	cases := []struct {
+		version string
+		status  Status
+	}{
+		{"1.20.0", Supported},
+		{"1.19.3", Supported},
+		{"1.18.0", Supported},
+		{"1.21.0-beta1", Supported},
+		{"2.0.0", Supported},
+		{"1.17.5", Deprecated},
+		{"1.16.1", EOL},
+		{"1.15.3", Supported},
+		{"1.15.0-rc1", Supported},
+		{"1.18.0-rc.1", Supported},
+		{"1.17.0-rc.1", Deprecated},
+		{"1.16.0-rc.1", EOL},
+		{"1.14.0", EOL},
+	}
+
+	releases := testReleases(t)
+	for _, tc := range cases {
+		v := version.Must(version.NewVersion(tc.version))
+		if actual := p.Status(releases, v); actual != tc.status {
+			t.Fatalf("%s: expected %s, got %s", tc.version, tc.status, actual)
+		}
+	}
+}
+
+func TestPolicyStatus_prerelease(t *testing.T) {
+	var releases version.Collection
+	for _, s := range []string{"1.14.0", "1.15.0", "1.16.0", "1.17.0", "1.18.0"} {
+		releases = append(releases, version.Must(version.NewVersion(s)))
+	}
+
+	p := &Policy{Minors: 3, DeprecatedMinors: 1}
+	cases := []struct {
+		version string
+		status  Status
+	}{
+		{"1.16.0-rc.1", Supported},
+		{"1.16.0", Supported},
+		{"1.15.0-rc.1", Deprecated},
+		{"1.15.0", Deprecated},
+		{"1.14.0-rc.1", EOL},
+		{"1.19.0-rc.1", Supported},
+	}
+
+	for _, tc := range cases {
+		v := version.Must(version.NewVersion(tc.version))
+		if actual := p.Status(releases, v); actual != tc.status {
+			t.Fatalf("%s: expected %s, got %s", tc.version, tc.status, actual)
+		}
+	}
+}
+
+func TestPolicyConstraint(t *testing.T) {
+	cases := []struct {
+		policy   *Policy
+		expected string
+	}{
+		{
+			&Policy{
+				Minors: 3,
+				LTS:    []*version.Version{version.Must(version.NewVersion("1.15.2"))},
+			},
+			">= 1.15.0, < 1.16.0 || >= 1.18.0",
+		},
+		{&Policy{Minors: 1}, ">= 1.20.0"},
+		{
+			&Policy{LTS: []*version.Version{version.Must(version.NewVersion("1.16"))}},
+			">= 1.16.0, < 1.17.0",
+		},
+		{&Policy{}, "< 0.0.0"},
+	}
+
+	releases := testReleases(t)
+	for _, tc := range cases {
+		if actual := tc.policy.Constraint(releases); actual != tc.expected {
+			t.Fatalf("%#v\nexpected: %s\nactual: %s", tc.policy, tc.expected, actual)
+		}
+	}
+}
+
+func TestPolicyConstraint_status(t *testing.T) {
+	p := &Policy{
+		Minors: 3,
+		LTS:    []*version.Version{version.Must(version.NewVersion("1.15.2"))},
+	}
+	releases := testReleases(t)
+	cs := version.MustConstraints(version.NewConstraint(">= 1.15.0, < 1.16.0"))
+	union := cs.Union(version.MustConstraints(version.NewConstraint(">= 1.18.0")))
+	if actual := p.Constraint(releases); actual != union.String() {
+		t.Fatalf("expected: %s\nactual: %s", union, actual)
+	}
+
+	for _, s := range []string{"1.14.9", "1.15.3", "1.17.0", "1.18.0", "1.19.1", "1.21.0"} {
+		v := version.Must(version.NewVersion(s))
+		if union.Check(v) != (p.Status(releases, v) == Supported) {
+			t.Fatalf("%s: constraint and status disagree", v)
+		}
+	}
+
+	// Pre-releases of supported lines do not satisfy the constraint
+	for _, s := range []string{"1.18.0-rc.1", "1.21.0-beta1"} {
+		v := version.Must(version.NewVersion(s))
+		if p.Status(releases, v) != Supported {
+			t.Fatalf("%s: expected supported", v)
+		}
+		if union.Check(v) {
+			t.Fatalf("%s: expected not to satisfy %s", v, union)
+		}
+	}
+}
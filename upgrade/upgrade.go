@@ -0,0 +1,171 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+// Package upgrade plans stepwise upgrades for products that cannot jump
+// directly from one release to any later one.
+package upgrade
+
+import (
+	"fmt"
+	"sort"
+
+	version "github.com/hashicorp/go-version"
+)
+
+// Rule restricts the steps of an upgrade plan.
+type Rule func(*rules)
+
+type rules struct {
+	noMajorSkips      bool
+	latestBeforeMajor bool
+	maxMinors         int
+}
+
+// NoMajorSkips is a Rule that allows each step to move to the next major
+// version at most, e.g. from 1.x to 2.x but not to 3.x.
+func NoMajorSkips() Rule {
+	return func(r *rules) {
+		r.noMajorSkips = true
+	}
+}
+
+// LatestMinorBeforeMajor is a Rule that requires landing on the latest
+// release of a major version before moving to a higher major version, and
+// so on the latest release of every major version in between.
+func LatestMinorBeforeMajor() Rule {
+	return func(r *rules) {
+		r.latestBeforeMajor = true
+	}
+}
+
+// MaxMinorsPerStep is a Rule that allows each step to move n minor
+// versions forward at most. A step to a new major version counts the
+// minors left up to the latest release of the current major, and those of
+// the new major from .0. So with n = 2, if 1.9 is the latest minor of 1.x,
+// a step from 1.9.x can reach 2.1.x but not 2.2.x, and a step from 1.8.x
+// can reach 2.0.x.
+func MaxMinorsPerStep(n int) Rule {
+	return func(r *rules) {
+		r.maxMinors = n
+	}
+}
+
+// Plan returns the ordered releases to upgrade through to get from the
+// current version to the target, ending with the target itself. Each step
+// goes as far as the rules allow, so the plan has as few steps as
+// possible. The current version is not part of the plan, which is empty
+// if current and target are the same version.
+//
+// Pre-releases are only used as a step if they are the target, which must
+// be one of the releases.
+func Plan(current, target *version.Version, releases version.Collection, opts ...Rule) (version.Collection, error) {
+	r := &rules{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(r)
+		}
+	}
+
+	if target.LessThan(current) {
+		return nil, fmt.Errorf("cannot upgrade from %s to lower version %s", current, target)
+	}
+
+	var candidates version.Collection
+	found := false
+	for _, v := range releases {
+		if v.Equal(target) {
+			found = true
+		} else if v.Prerelease() != "" {
+			continue
+		}
+		if v.GreaterThan(current) && v.LessThanOrEqual(target) {
+			candidates = append(candidates, v)
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("target version %s is not one of the releases", target)
+	}
+	sort.Stable(candidates)
+
+	latest := latestOfMajors(append(version.Collection{current}, releases...))
+
+	var plan version.Collection
+	from := current
+	for !from.Equal(target) {
+		var next *version.Version
+		for i := len(candidates) - 1; i >= 0 && candidates[i].GreaterThan(from); i-- {
+			if r.allows(from, candidates[i], latest) {
+				next = candidates[i]
+				break
+			}
+		}
+		if next == nil {
+			return nil, fmt.Errorf("no upgrade path from %s to %s: no allowed step from %s", current, target, from)
+		}
+
+		plan = append(plan, next)
+		from = next
+	}
+
+	return plan, nil
+}
+
+// allows tests if the rules allow a single step between two versions.
+func (r *rules) allows(from, to *version.Version, latest map[int64]*version.Version) bool {
+	fromSegments, toSegments := from.Segments64(), to.Segments64()
+	majors := toSegments[0] - fromSegments[0]
+	if majors == 0 {
+		return r.maxMinors <= 0 || toSegments[1]-fromSegments[1] <= int64(r.maxMinors)
+	}
+
+	if r.noMajorSkips && majors > 1 {
+		return false
+	}
+	if r.latestBeforeMajor {
+		// Without any release in its major, such as for a pre-release,
+		// from is the latest of its major
+		if l, ok := latest[fromSegments[0]]; ok && !from.Equal(l) {
+			return false
+		}
+		for major := fromSegments[0] + 1; major < toSegments[0]; major++ {
+			if _, ok := latest[major]; ok {
+				return false
+			}
+		}
+	}
+	if r.maxMinors > 0 {
+		if majors > 1 {
+			return false
+		}
+
+		// Count the minors left in the current major, then those of the
+		// new major from .0
+		var skipped int64
+		if l, ok := latest[fromSegments[0]]; ok {
+			skipped = l.Segments64()[1] - fromSegments[1]
+		}
+		if skipped+toSegments[1]+1 > int64(r.maxMinors) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// latestOfMajors returns the latest release, excluding pre-releases, of
+// each major version.
+func latestOfMajors(releases version.Collection) map[int64]*version.Version {
+	latest := make(map[int64]*version.Version)
+	for _, v := range releases {
+		if v.Prerelease() != "" {
+			continue
+		}
+
+		major := v.Segments64()[0]
+		if l, ok := latest[major]; !ok || v.GreaterThan(l) {
+			latest[major] = v
+		}
+	}
+
+	return latest
+}
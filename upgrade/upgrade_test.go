@@ -0,0 +1,144 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package upgrade
+
+import (
+	"strings"
+	"testing"
+
+	version "github.com/hashicorp/go-version"
+)
+
+func collection(t *testing.T, vs ...string) version.Collection {
+	t.Helper()
+
+	result := make(version.Collection, len(vs))
+	for i, s := range vs {
+		v, err := version.NewVersion(s)
+		if err != nil {
+			t.Fatalf("error parsing %s: %s", s, err)
+		}
+		result[i] = v
+	}
+
+	return result
+}
+
+func TestPlan(t *testing.T) {
+	releases := []string{
+		"1.0.0", "1.1.0", "1.2.0", "1.3.0", "1.4.0", "1.4.1",
+		"2.0.0", "2.1.0", "2.2.0", "2.3.0-beta1",
+		"3.0.0", "3.1.0",
+	}
+
+	cases := []struct {
+		current string
+		target  string
+		rules   []Rule
+		plan    string
+	}{
+		{"1.0.0", "3.1.0", nil, "3.1.0"},
+		{"1.0.0", "1.0.0", nil, ""},
+		{"1.0.0", "3.1.0", []Rule{NoMajorSkips()}, "2.2.0 3.1.0"},
+		{"1.0.0", "3.1.0", []Rule{LatestMinorBeforeMajor()}, "1.4.1 2.2.0 3.1.0"},
+		{
+			"1.0.0", "3.1.0",
+			[]Rule{NoMajorSkips(), LatestMinorBeforeMajor()},
+			"1.4.1 2.2.0 3.1.0",
+		},
+		{"1.0.0", "1.4.1", []Rule{MaxMinorsPerStep(2)}, "1.2.0 1.4.1"},
+		{"1.3.0", "2.2.0", []Rule{MaxMinorsPerStep(2)}, "2.0.0 2.2.0"},
+		{"1.0.0", "2.2.0", []Rule{MaxMinorsPerStep(2)}, "1.2.0 1.4.1 2.1.0 2.2.0"},
+		{"1.3.0", "2.2.0", []Rule{MaxMinorsPerStep(2), LatestMinorBeforeMajor()}, "1.4.1 2.1.0 2.2.0"},
+		{"1.4.1", "3.0.0", []Rule{MaxMinorsPerStep(1)}, "2.0.0 2.1.0 2.2.0 3.0.0"},
+		{"2.1.0", "2.3.0-beta1", nil, "2.3.0-beta1"},
+		{"2.0.0", "3.1.0", []Rule{MaxMinorsPerStep(1)}, "2.1.0 2.2.0 3.0.0 3.1.0"},
+		{"0.9.0", "2.0.0", []Rule{LatestMinorBeforeMajor()}, "1.4.1 2.0.0"},
+	}
+
+	for _, tc := range cases {
+		current := version.Must(version.NewVersion(tc.current))
+		target := version.Must(version.NewVersion(tc.target))
+
+		plan, err := Plan(current, target, collection(t, releases...), tc.rules...)
+		if err != nil {
+			t.Fatalf("%s to %s: %s", tc.current, tc.target, err)
+		}
+
+		hops := make([]string, len(plan))
+		for i, v := range plan {
+			hops[i] = v.String()
+		}
+		if actual := strings.Join(hops, " "); actual != tc.plan {
+			t.Fatalf("%s to %s\nexpected: %s\nactual: %s", tc.current, tc.target, tc.plan, actual)
+		}
+	}
+}
+
+func TestPlan_prereleaseCurrent(t *testing.T) {
+	// The major of the current version has no release to count the
+	// skipped minors from
+	current := version.Must(version.NewVersion("1.0.0-rc.1"))
+	target := version.Must(version.NewVersion("2.1.0"))
+	releases := collection(t, "2.0.0", "2.1.0")
+
+	cases := []struct {
+		rules []Rule
+		plan  string
+	}{
+		{[]Rule{MaxMinorsPerStep(2)}, "2.1.0"},
+		{[]Rule{MaxMinorsPerStep(1)}, "2.0.0 2.1.0"},
+		{[]Rule{LatestMinorBeforeMajor()}, "2.1.0"},
+	}
+
+	for _, tc := range cases {
+		plan, err := Plan(current, target, releases, tc.rules...)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		hops := make([]string, len(plan))
+		for i, v := range plan {
+			hops[i] = v.String()
+		}
+		if actual := strings.Join(hops, " "); actual != tc.plan {
+			t.Fatalf("expected: %s\nactual: %s", tc.plan, actual)
+		}
+	}
+}
+
+func TestPlan_errors(t *testing.T) {
+	releases := collection(t, "1.0.0", "1.1.0", "3.0.0")
+
+	cases := []struct {
+		current string
+		target  string
+		rules   []Rule
+		err     string
+	}{
+		{"1.1.0", "1.0.0", nil, "cannot upgrade from 1.1.0 to lower version 1.0.0"},
+		{"1.0.0", "2.0.0", nil, "target version 2.0.0 is not one of the releases"},
+		{
+			"1.0.0", "3.0.0", []Rule{NoMajorSkips()},
+			"no upgrade path from 1.0.0 to 3.0.0: no allowed step from 1.1.0",
+		},
+		{
+			"0.9.0-rc.1", "3.0.0", []Rule{MaxMinorsPerStep(1), LatestMinorBeforeMajor()},
+			"no upgrade path from 0.9.0-rc.1 to 3.0.0: no allowed step from 1.1.0",
+		},
+	}
+
+	for _, tc := range cases {
+		current := version.Must(version.NewVersion(tc.current))
+		target := version.Must(version.NewVersion(tc.target))
+
+		_, err := Plan(current, target, releases, tc.rules...)
+		if err == nil {
+			t.Fatalf("%s to %s: expected error", tc.current, tc.target)
+		}
+		if err.Error() != tc.err {
+			t.Fatalf("%s to %s\nexpected: %s\nactual: %s", tc.current, tc.target, tc.err, err)
+		}
+	}
+}
@@ -168,7 +168,7 @@ func Must(v *Version, err error) *Version {
 // GreaterThan, GreaterThanOrEqual or LessThanOrEqual methods.
 func (v *Version) Compare(other *Version) int {
 	// A quick, efficient equality check
-	if v.String() == other.String() {
+	if v == other {
 		return 0
 	}
 
@@ -189,8 +189,9 @@ func (v *Version) Compare(other *Version) int {
 		return comparePrereleases(preSelf, preOther)
 	}
 
-	segmentsSelf := v.Segments64()
-	segmentsOther := other.Segments64()
+	// The segments are only read here, so avoid the copies made by Segments64
+	segmentsSelf := v.segments
+	segmentsOther := other.segments
 	// Get the highest specificity (hS), or if they're equal, just use segmentSelf length
 	lenSelf := len(segmentsSelf)
 	lenOther := len(segmentsOther)
@@ -235,8 +236,8 @@ func (v *Version) Compare(other *Version) int {
 }
 
 func (v *Version) equalSegments(other *Version) bool {
-	segmentsSelf := v.Segments64()
-	segmentsOther := other.Segments64()
+	segmentsSelf := v.segments
+	segmentsOther := other.segments
 
 	if len(segmentsSelf) != len(segmentsOther) {
 		return false
@@ -263,19 +264,8 @@ func comparePart(preSelf string, preOther string) int {
 		return 0
 	}
 
-	var selfInt int64
-	selfNumeric := true
-	selfInt, err := strconv.ParseInt(preSelf, 10, 64)
-	if err != nil {
-		selfNumeric = false
-	}
-
-	var otherInt int64
-	otherNumeric := true
-	otherInt, err = strconv.ParseInt(preOther, 10, 64)
-	if err != nil {
-		otherNumeric = false
-	}
+	selfInt, selfNumeric := parseNumericPart(preSelf)
+	otherInt, otherNumeric := parseNumericPart(preOther)
 
 	// if a part is empty, we use the other to decide
 	if preSelf == "" {
@@ -305,35 +295,50 @@ func comparePart(preSelf string, preOther string) int {
 	return -1
 }
 
-func comparePrereleases(v string, other string) int {
-	// the same pre release!
-	if v == other {
-		return 0
+// parseNumericPart parses a pre-release part as an int64, the same way
+// strconv.ParseInt would, but without allocating an error for the common
+// non-numeric parts.
+func parseNumericPart(part string) (int64, bool) {
+	digits := part
+	if len(digits) > 0 && (digits[0] == '+' || digits[0] == '-') {
+		digits = digits[1:]
+	}
+	if digits == "" {
+		return 0, false
+	}
+	for i := 0; i < len(digits); i++ {
+		if digits[i] < '0' || digits[i] > '9' {
+			return 0, false
+		}
 	}
 
-	// split both pre releases for analyse their parts
-	selfPreReleaseMeta := strings.Split(v, ".")
-	otherPreReleaseMeta := strings.Split(other, ".")
-
-	selfPreReleaseLen := len(selfPreReleaseMeta)
-	otherPreReleaseLen := len(otherPreReleaseMeta)
+	val, err := strconv.ParseInt(part, 10, 64)
+	return val, err == nil
+}
 
-	biggestLen := otherPreReleaseLen
-	if selfPreReleaseLen > otherPreReleaseLen {
-		biggestLen = selfPreReleaseLen
+// nextPrereleasePart returns the first dot-separated part of a pre-release
+// and the rest of it.
+func nextPrereleasePart(pre string) (string, string) {
+	i := strings.IndexByte(pre, '.')
+	if i < 0 {
+		return pre, ""
 	}
 
-	// loop for parts to find the first difference
-	for i := 0; i < biggestLen; i = i + 1 {
-		partSelfPre := ""
-		if i < selfPreReleaseLen {
-			partSelfPre = selfPreReleaseMeta[i]
-		}
+	return pre[:i], pre[i+1:]
+}
 
-		partOtherPre := ""
-		if i < otherPreReleaseLen {
-			partOtherPre = otherPreReleaseMeta[i]
-		}
+func comparePrereleases(v string, other string) int {
+	// the same pre release!
+	if v == other {
+		return 0
+	}
+
+	// loop for parts to find the first difference, a missing part being
+	// treated as empty
+	for v != "" || other != "" {
+		var partSelfPre, partOtherPre string
+		partSelfPre, v = nextPrereleasePart(v)
+		partOtherPre, other = nextPrereleasePart(other)
 
 		compare := comparePart(partSelfPre, partOtherPre)
 		// if parts are equals, continue the loop
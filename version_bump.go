@@ -0,0 +1,127 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package version
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	channelRegexp     *regexp.Regexp
+	channelRegexpOnce sync.Once
+)
+
+func getChannelRegexp() *regexp.Regexp {
+	channelRegexpOnce.Do(func() {
+		channelRegexp = regexp.MustCompile(`^[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*$`)
+	})
+	return channelRegexp
+}
+
+// ValidatePrereleaseChannel returns an error if channel cannot be used with
+// BumpPrerelease: it must be made of dot-separated identifiers of ASCII
+// alphanumerics and hyphens, such as "rc" or "beta.linux".
+func ValidatePrereleaseChannel(channel string) error {
+	if !getChannelRegexp().MatchString(channel) {
+		return fmt.Errorf("invalid pre-release channel %q", channel)
+	}
+
+	return nil
+}
+
+// BumpMajor returns the next major version, such as 2.0.0 for 1.4.2.
+//
+// Like the other bump methods, it drops any metadata, and a pre-release
+// of the version it would bump to is released instead: 2.0.0-rc.1 bumps
+// to 2.0.0, while 2.1.0-rc.1 bumps to 3.0.0.
+func (v *Version) BumpMajor() *Version {
+	return v.bump(0)
+}
+
+// BumpMinor returns the next minor version, such as 1.5.0 for 1.4.2.
+func (v *Version) BumpMinor() *Version {
+	return v.bump(1)
+}
+
+// BumpPatch returns the next patch version, such as 1.4.3 for 1.4.2.
+func (v *Version) BumpPatch() *Version {
+	return v.bump(2)
+}
+
+// BumpPrerelease returns the next pre-release of the given channel, such
+// as "rc". Pre-releases of a channel are numbered from 1, and a release
+// bumps to the first pre-release of its next patch version:
+//
+//	1.4.2        -> 1.4.3-rc.1
+//	1.4.3-rc.1   -> 1.4.3-rc.2
+//	1.4.3-rc.5.1 -> 1.4.3-rc.6
+//	1.4.3-beta.2 -> 1.4.3-rc.1
+//
+// An error is returned if the channel is not valid (see
+// ValidatePrereleaseChannel), or if the next pre-release would not be
+// greater than v, as for 1.4.3-rc.x or 1.4.3-rc.1 to the "beta" channel.
+func (v *Version) BumpPrerelease(channel string) (*Version, error) {
+	if err := ValidatePrereleaseChannel(channel); err != nil {
+		return nil, err
+	}
+
+	if v.pre == "" {
+		next := v.BumpPatch()
+		next.pre = channel + ".1"
+		next.original = next.String()
+		return next, nil
+	}
+
+	n := int64(1)
+	if rest := strings.TrimPrefix(v.pre, channel+"."); rest != v.pre {
+		part, _ := nextPrereleasePart(rest)
+		current, err := strconv.ParseInt(part, 10, 64)
+		if !isDigits(part) || err != nil || current == math.MaxInt64 {
+			return nil, fmt.Errorf("cannot bump pre-release %q of channel %q", v.pre, channel)
+		}
+		n = current + 1
+	}
+
+	next := newVersionFromSegments(v.Segments64())
+	next.pre = channel + "." + strconv.FormatInt(n, 10)
+	next.original = next.String()
+	if !next.GreaterThan(v) {
+		return nil, fmt.Errorf("pre-release %s is not greater than %s", next, v)
+	}
+
+	return next, nil
+}
+
+// isDigits tests if s is a non-empty string of ASCII digits, without sign.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// bump returns the version with the segment at index incremented, and the
+// following segments set to zero.
+func (v *Version) bump(index int) *Version {
+	segments := v.Segments64()
+	if v.pre == "" || !allZero(segments[index+1:]) {
+		segments[index]++
+		for i := index + 1; i < len(segments); i++ {
+			segments[i] = 0
+		}
+	}
+
+	return newVersionFromSegments(segments)
+}
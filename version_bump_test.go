@@ -0,0 +1,124 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package version
+
+import (
+	"testing"
+)
+
+func TestVersionBump(t *testing.T) {
+	cases := []struct {
+		version string
+		major   string
+		minor   string
+		patch   string
+	}{
+		{"1.4.2", "2.0.0", "1.5.0", "1.4.3"},
+		{"v1.4", "2.0.0", "1.5.0", "1.4.1"},
+		{"1.4.2+ent", "2.0.0", "1.5.0", "1.4.3"},
+		{"1.4.2.7", "2.0.0.0", "1.5.0.0", "1.4.3.0"},
+		{"2.0.0-rc.1", "2.0.0", "2.0.0", "2.0.0"},
+		{"2.1.0-rc.1", "3.0.0", "2.1.0", "2.1.0"},
+		{"2.1.3-rc.1", "3.0.0", "2.2.0", "2.1.3"},
+		{"0.0.0", "1.0.0", "0.1.0", "0.0.1"},
+	}
+
+	for _, tc := range cases {
+		v, err := NewVersion(tc.version)
+		if err != nil {
+			t.Fatalf("error for version %q: %s", tc.version, err)
+		}
+
+		if actual := v.BumpMajor().String(); actual != tc.major {
+			t.Fatalf("%s major: expected %s, got %s", tc.version, tc.major, actual)
+		}
+		if actual := v.BumpMinor().String(); actual != tc.minor {
+			t.Fatalf("%s minor: expected %s, got %s", tc.version, tc.minor, actual)
+		}
+		if actual := v.BumpPatch().String(); actual != tc.patch {
+			t.Fatalf("%s patch: expected %s, got %s", tc.version, tc.patch, actual)
+		}
+	}
+}
+
+func TestVersionBumpPrerelease(t *testing.T) {
+	cases := []struct {
+		version  string
+		channel  string
+		expected string
+		err      bool
+	}{
+		{"1.4.2", "rc", "1.4.3-rc.1", false},
+		{"1.4.3-rc.1", "rc", "1.4.3-rc.2", false},
+		{"1.4.3-rc.9+meta", "rc", "1.4.3-rc.10", false},
+		{"1.4.3-rc", "rc", "1.4.3-rc.1", false},
+		{"1.4.3-rc.5.1", "rc", "1.4.3-rc.6", false},
+		{"1.4.3-beta.2", "rc", "1.4.3-rc.1", false},
+		{"1.4.3-rcx.2", "rc", "", true},
+		{"1.4.3-alpha-1.1", "alpha-1", "1.4.3-alpha-1.2", false},
+		{"1.4.3-rc.x", "rc", "", true},
+		{"1.4.3-rc.-3", "rc", "", true},
+		{"1.4.3-rc.9223372036854775807", "rc", "", true},
+		{"1.4.3-rc.1", "beta", "", true},
+		{"1.4.2", "rc_1", "", true},
+		{"1.4.2", "", "", true},
+		{"1.4.2", "rc..1", "", true},
+		{"1.4.2", "rc+1", "", true},
+	}
+
+	for _, tc := range cases {
+		v, err := NewVersion(tc.version)
+		if err != nil {
+			t.Fatalf("error for version %q: %s", tc.version, err)
+		}
+
+		actual, err := v.BumpPrerelease(tc.channel)
+		if tc.err {
+			if err == nil {
+				t.Fatalf("%s %q: expected error, got %s", tc.version, tc.channel, actual)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s %q: err: %s", tc.version, tc.channel, err)
+		}
+
+		if actual.String() != tc.expected {
+			t.Fatalf("%s: expected %s, got %s", tc.version, tc.expected, actual)
+		}
+		if actual.Original() != tc.expected {
+			t.Fatalf("%s: bad original: %s", tc.version, actual.Original())
+		}
+		if !actual.GreaterThan(v) {
+			t.Fatalf("%s: %s is not greater", tc.version, actual)
+		}
+		if _, err := NewVersion(actual.String()); err != nil {
+			t.Fatalf("%s: %s does not parse: %s", tc.version, actual, err)
+		}
+	}
+}
+
+func TestValidatePrereleaseChannel(t *testing.T) {
+	cases := []struct {
+		channel string
+		valid   bool
+	}{
+		{"rc", true},
+		{"beta.linux", true},
+		{"alpha-1", true},
+		{"0", true},
+		{"", false},
+		{"rc_1", false},
+		{"rc..1", false},
+		{"rc.", false},
+		{"rc+1", false},
+	}
+
+	for _, tc := range cases {
+		err := ValidatePrereleaseChannel(tc.channel)
+		if (err == nil) != tc.valid {
+			t.Fatalf("%q: expected valid %t, got %v", tc.channel, tc.valid, err)
+		}
+	}
+}
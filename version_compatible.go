@@ -0,0 +1,59 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package version
+
+// IsCompatibleWith tests if v can replace other without breaking its
+// callers, following SemVer: v must not be lower than other, and must
+// have the same major version. While the major version is 0, the API is
+// unstable, so v must also have the same minor version, or for 0.0.z the
+// same patch version.
+//
+// For example, 1.5.0 is compatible with 1.2.3, but 1.2.3 is not
+// compatible with 1.5.0, nor 0.3.0 with 0.2.3. A pre-release is only
+// compatible with a pre-release of the same segments, so 1.3.0-beta is
+// not compatible with 1.2.3, while 1.2.3-beta.3 is with 1.2.3-beta.2.
+//
+// This is the same as checking v against other.CompatibleConstraints.
+func (v *Version) IsCompatibleWith(other *Version) bool {
+	return other.CompatibleConstraints().Check(v)
+}
+
+// CompatibleConstraints returns the constraints matching the versions
+// compatible with v, which is known as the caret range "^1.2.3" in other
+// version schemes:
+//
+//	1.2.3        -> >= 1.2.3, < 2.0.0
+//	0.2.3        -> >= 0.2.3, < 0.3.0
+//	0.0.3        -> >= 0.0.3, < 0.0.4
+//	1.2.3-beta.2 -> >= 1.2.3-beta.2, < 2.0.0
+//
+// The metadata of v is dropped. The constraints use the
+// PrereleaseSameSegments policy, so pre-releases only satisfy them if v is
+// a pre-release with the same segments: 1.2.3-beta.3 satisfies the
+// constraints of 1.2.3-beta.2, but 2.0.0-rc.1 and 1.4.0-rc.1 do not.
+func (v *Version) CompatibleConstraints() Constraints {
+	lower := newVersionFromSegments(v.Segments64())
+	lower.pre = v.pre
+	lower.original = lower.String()
+
+	cs := Constraints{
+		newConstraint(OpGreaterThanEqual, lower),
+		newConstraint(OpLessThan, incrementLastSegment(v.compatibleSegments())),
+	}
+	applyConstraintOptions(cs, []ConstraintOption{WithPrereleasePolicy(PrereleaseSameSegments)})
+
+	return cs
+}
+
+// compatibleSegments returns the leading segments that a version
+// compatible with v must have: up to the first non-zero one of major,
+// minor and patch.
+func (v *Version) compatibleSegments() []int64 {
+	i := 0
+	for i < 2 && v.segments[i] == 0 {
+		i++
+	}
+
+	return v.segments[:i+1]
+}
@@ -0,0 +1,117 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package version
+
+import (
+	"testing"
+)
+
+func TestVersionIsCompatibleWith(t *testing.T) {
+	cases := []struct {
+		version  string
+		other    string
+		expected bool
+	}{
+		{"1.2.3", "1.2.3", true},
+		{"1.5.0", "1.2.3", true},
+		{"1.2.3+ent", "1.2.3", true},
+		{"1.2.3", "1.5.0", false},
+		{"2.0.0", "1.2.3", false},
+		{"2.0.0-rc.1", "1.2.3", false},
+		{"1.3.0-beta", "1.2.3", false},
+		{"1.2.3-beta.3", "1.2.3-beta.2", true},
+		{"1.2.3", "1.2.3-beta.2", true},
+		{"1.2.3-beta.1", "1.2.3-beta.2", false},
+		{"0.2.9", "0.2.3", true},
+		{"0.3.0", "0.2.3", false},
+		{"0.0.3", "0.0.3", true},
+		{"0.0.4", "0.0.3", false},
+		{"0.0.3.1", "0.0.3", true},
+		{"1.2.3.1", "1.2.3.2", false},
+		{"0.1.0", "0.0.0", false},
+	}
+
+	for _, tc := range cases {
+		v := Must(NewVersion(tc.version))
+		other := Must(NewVersion(tc.other))
+
+		if actual := v.IsCompatibleWith(other); actual != tc.expected {
+			t.Fatalf("%s compatible with %s: expected %t", tc.version, tc.other, tc.expected)
+		}
+	}
+}
+
+func TestVersionCompatibleConstraints(t *testing.T) {
+	cases := []struct {
+		version    string
+		constraint string
+		check      map[string]bool
+	}{
+		{
+			"1.2.3", ">= 1.2.3,< 2.0.0",
+			map[string]bool{"1.2.3": true, "1.9.0": true, "1.2.2": false, "2.0.0": false, "2.0.0-rc.1": false},
+		},
+		{
+			"v0.2.3+ent", ">= 0.2.3,< 0.3.0",
+			map[string]bool{"0.2.3": true, "0.2.10": true, "0.3.0": false},
+		},
+		{
+			"0.0.3", ">= 0.0.3,< 0.0.4",
+			map[string]bool{"0.0.3": true, "0.0.4": false},
+		},
+		{
+			"1.2.3-beta.2", ">= 1.2.3-beta.2,< 2.0.0",
+			map[string]bool{"1.2.3-beta.3": true, "1.2.3": true, "1.2.3-beta.1": false, "1.4.0-rc.1": false},
+		},
+		{
+			"1.2", ">= 1.2.0,< 2.0.0",
+			map[string]bool{"1.2.0": true},
+		},
+	}
+
+	for _, tc := range cases {
+		v := Must(NewVersion(tc.version))
+		cs := v.CompatibleConstraints()
+		if actual := cs.String(); actual != tc.constraint {
+			t.Fatalf("%s: expected %q, got %q", tc.version, tc.constraint, actual)
+		}
+
+		for raw, expected := range tc.check {
+			if actual := cs.Check(Must(NewVersion(raw))); actual != expected {
+				t.Fatalf("%s: check %s: expected %t", tc.constraint, raw, expected)
+			}
+		}
+
+		// The constraints must round trip through NewConstraint
+		parsed, err := NewConstraint(cs.String(), WithPrereleasePolicy(PrereleaseSameSegments))
+		if err != nil {
+			t.Fatalf("%s: err: %s", tc.constraint, err)
+		}
+		if !parsed.Equals(cs) {
+			t.Fatalf("%s: does not round trip", tc.constraint)
+		}
+	}
+}
+
+func TestVersionIsCompatibleWith_matchesConstraints(t *testing.T) {
+	raw := []string{
+		"0.0.0", "0.0.3", "0.0.3.1", "0.0.4", "0.2.3", "0.2.9", "0.3.0",
+		"1.0.0-rc.1", "1.2.3-beta.2", "1.2.3-beta.3", "1.2.3", "1.2.3+ent",
+		"1.2.3.1", "1.3.0-beta", "1.5.0", "2.0.0-rc.1", "2.0.0",
+	}
+
+	versions := make([]*Version, len(raw))
+	for i, s := range raw {
+		versions[i] = Must(NewVersion(s))
+	}
+
+	for _, other := range versions {
+		cs := other.CompatibleConstraints()
+		for _, v := range versions {
+			if v.IsCompatibleWith(other) != cs.Check(v) {
+				t.Fatalf("%s compatible with %s disagrees with %s", v, other, cs)
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package version
+
+// Change is the most significant part that differs between two versions.
+type Change int
+
+const (
+	ChangeNone Change = iota
+	ChangeMetadata
+	ChangePrerelease
+	ChangePatch
+	ChangeMinor
+	ChangeMajor
+)
+
+func (c Change) String() string {
+	switch c {
+	case ChangeMetadata:
+		return "metadata"
+	case ChangePrerelease:
+		return "prerelease"
+	case ChangePatch:
+		return "patch"
+	case ChangeMinor:
+		return "minor"
+	case ChangeMajor:
+		return "major"
+	default:
+		return "none"
+	}
+}
+
+// Direction tells if a change between two versions is an upgrade or a
+// downgrade.
+type Direction int
+
+const (
+	DirectionNone Direction = iota
+	DirectionUpgrade
+	DirectionDowngrade
+)
+
+func (d Direction) String() string {
+	switch d {
+	case DirectionUpgrade:
+		return "upgrade"
+	case DirectionDowngrade:
+		return "downgrade"
+	default:
+		return "none"
+	}
+}
+
+// Difference describes how a version differs from another. See Diff.
+type Difference struct {
+	Change    Change
+	Direction Direction
+
+	// Segment is the index of the first segment that differs, or -1 if
+	// the segments are equal.
+	Segment int
+
+	// Deltas are the differences between each segment of the versions,
+	// as many as the segments of the longest one, a missing segment
+	// being 0.
+	Deltas []int64
+}
+
+// Diff returns how b differs from a. For example, from 1.4.2 to
+// 2.0.0-rc.1 is a major upgrade, with deltas 1, -4 and -2.
+//
+// A change to any segment after the third is a patch change, as with
+// 1.4.2.1 to 1.4.2.2, and Segment tells which one it is. A change of
+// metadata only has no direction, as the versions are equal.
+func Diff(a, b *Version) *Difference {
+	n := len(a.segments)
+	if len(b.segments) > n {
+		n = len(b.segments)
+	}
+
+	d := &Difference{Segment: -1, Deltas: make([]int64, n)}
+	for i := range d.Deltas {
+		var x, y int64
+		if i < len(a.segments) {
+			x = a.segments[i]
+		}
+		if i < len(b.segments) {
+			y = b.segments[i]
+		}
+
+		d.Deltas[i] = y - x
+		if d.Deltas[i] != 0 && d.Segment < 0 {
+			d.Segment = i
+		}
+	}
+
+	switch {
+	case d.Segment == 0:
+		d.Change = ChangeMajor
+	case d.Segment == 1:
+		d.Change = ChangeMinor
+	case d.Segment >= 2:
+		d.Change = ChangePatch
+	case a.pre != b.pre:
+		d.Change = ChangePrerelease
+	case a.metadata != b.metadata:
+		d.Change = ChangeMetadata
+	}
+
+	switch cmp := a.Compare(b); {
+	case cmp < 0:
+		d.Direction = DirectionUpgrade
+	case cmp > 0:
+		d.Direction = DirectionDowngrade
+	}
+
+	return d
+}
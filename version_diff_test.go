@@ -0,0 +1,45 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package version
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	cases := []struct {
+		a         string
+		b         string
+		change    Change
+		direction Direction
+		segment   int
+		deltas    []int64
+	}{
+		{"1.4.2", "2.0.0-rc.1", ChangeMajor, DirectionUpgrade, 0, []int64{1, -4, -2}},
+		{"1.4.2", "1.5.0", ChangeMinor, DirectionUpgrade, 1, []int64{0, 1, -2}},
+		{"1.4.2", "1.4.1", ChangePatch, DirectionDowngrade, 2, []int64{0, 0, -1}},
+		{"1.4.2-rc.1", "1.4.2", ChangePrerelease, DirectionUpgrade, -1, []int64{0, 0, 0}},
+		{"1.4.2-rc.2", "1.4.2-rc.1", ChangePrerelease, DirectionDowngrade, -1, []int64{0, 0, 0}},
+		{"1.4.2", "1.4.2+ent", ChangeMetadata, DirectionNone, -1, []int64{0, 0, 0}},
+		{"1.4.2", "v1.4.2", ChangeNone, DirectionNone, -1, []int64{0, 0, 0}},
+		{"1.4.2.1", "1.4.2.3", ChangePatch, DirectionUpgrade, 3, []int64{0, 0, 0, 2}},
+		{"1.4.2.1", "1.4.2", ChangePatch, DirectionDowngrade, 3, []int64{0, 0, 0, -1}},
+		{"1.4", "1.4.0.0", ChangeNone, DirectionNone, -1, []int64{0, 0, 0, 0}},
+	}
+
+	for _, tc := range cases {
+		a := Must(NewVersion(tc.a))
+		b := Must(NewVersion(tc.b))
+
+		d := Diff(a, b)
+		if d.Change != tc.change || d.Direction != tc.direction || d.Segment != tc.segment {
+			t.Fatalf("%s -> %s: expected %s %s at %d, got %s %s at %d",
+				tc.a, tc.b, tc.change, tc.direction, tc.segment, d.Change, d.Direction, d.Segment)
+		}
+		if !reflect.DeepEqual(d.Deltas, tc.deltas) {
+			t.Fatalf("%s -> %s: expected deltas %v, got %v", tc.a, tc.b, tc.deltas, d.Deltas)
+		}
+	}
+}
@@ -0,0 +1,345 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package version
+
+import (
+	"sort"
+)
+
+// Bound is one end of a Range. A nil Version means that the range is
+// unbounded in that direction.
+type Bound struct {
+	Version   *Version
+	Inclusive bool
+}
+
+// Range is an interval of versions, such as ">= 1.0, < 2.0".
+//
+// Ranges are defined purely in terms of the ordering of Version.Compare.
+// The pre-release rules applied by Constraint.Check (for example that
+// ">= 1.0" does not match "1.5.0-beta") are not part of a Range, which
+// matches what Check does with the PrereleaseInclude policy.
+type Range struct {
+	Lower Bound
+	Upper Bound
+}
+
+// Contains tests if the version falls within the range.
+func (r Range) Contains(v *Version) bool {
+	if r.Lower.Version != nil {
+		cmp := v.Compare(r.Lower.Version)
+		if cmp < 0 || (cmp == 0 && !r.Lower.Inclusive) {
+			return false
+		}
+	}
+	if r.Upper.Version != nil {
+		cmp := v.Compare(r.Upper.Version)
+		if cmp > 0 || (cmp == 0 && !r.Upper.Inclusive) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsEmpty returns true if no version can fall within the range.
+func (r Range) IsEmpty() bool {
+	if r.Lower.Version == nil || r.Upper.Version == nil {
+		return false
+	}
+
+	cmp := r.Lower.Version.Compare(r.Upper.Version)
+	return cmp > 0 || (cmp == 0 && !(r.Lower.Inclusive && r.Upper.Inclusive))
+}
+
+// String returns the range in constraint syntax, such as
+// ">= 1.0.0, < 2.0.0".
+//
+// The constraint syntax has no way to say "any version" or "no version",
+// so a range without bounds is returned as ">= 0.0.0" and an empty range
+// as "< 0.0.0".
+func (r Range) String() string {
+	if r.IsEmpty() {
+		return "< 0.0.0"
+	}
+
+	return constraintsGroupString(rangeConstraints(r, nil))
+}
+
+// Ranges returns the versions allowed by the constraints as a sorted
+// union of disjoint ranges. An empty result means that no version can
+// satisfy the constraints.
+//
+// The implied upper bound of "~>" and the gaps left by "!=" are part of
+// the result, e.g. "~> 1.2, != 1.5.0" becomes ">= 1.2.0, < 1.5.0" and
+// "> 1.5.0, < 2.0.0". As with Range, pre-release rules are not taken into
+// account, except for "~>" with a pre-release and PrereleaseStrict, which
+// only allows pre-releases: "~> 1.2.0-beta" becomes ">= 1.2.0-beta,
+// < 1.2.0".
+func (cs Constraints) Ranges() []Range {
+	result := []Range{{}}
+	for _, c := range cs {
+		result = intersectRanges(result, c.ranges())
+	}
+
+	return result
+}
+
+// IsSubsetOf tests if every version that satisfies cs also satisfies
+// other, e.g. "~> 1.2.3" is a subset of ">= 1.0, < 2.0".
+//
+// This is decided from the operators and versions of both constraints
+// (see Ranges) rather than by checking sample versions. Pre-release rules
+// are not taken into account, so the result may not hold for pre-releases
+// if cs and other use different PrereleasePolicy values: ">= 1.0" with
+// PrereleaseInclude is a subset of ">= 1.0" with PrereleaseStrict, though
+// only the former is satisfied by 1.5.0-beta.
+func (cs Constraints) IsSubsetOf(other Constraints) bool {
+	outer := other.Ranges()
+	for _, r := range cs.Ranges() {
+		contained := false
+		for _, o := range outer {
+			if o.containsRange(r) {
+				contained = true
+				break
+			}
+		}
+		if !contained {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Implies tests if satisfying cs means that other is satisfied too. This
+// is the same as cs.IsSubsetOf(other), with the same caveat about
+// pre-release policies.
+func (cs Constraints) Implies(other Constraints) bool {
+	return cs.IsSubsetOf(other)
+}
+
+// containsRange tests if all versions within o also fall within r.
+func (r Range) containsRange(o Range) bool {
+	return compareLower(r.Lower, o.Lower) <= 0 && compareUpper(o.Upper, r.Upper) <= 0
+}
+
+// ranges returns the versions allowed by a single constraint.
+func (c *Constraint) ranges() []Range {
+	if c.wildcard {
+		return c.wildcardRanges()
+	}
+
+	v := c.check
+	switch c.op {
+	case OpNotEqual:
+		return []Range{
+			{Upper: Bound{Version: v}},
+			{Lower: Bound{Version: v}},
+		}
+	case OpGreaterThan:
+		return []Range{{Lower: Bound{Version: v}}}
+	case OpLessThan:
+		return []Range{{Upper: Bound{Version: v}}}
+	case OpGreaterThanEqual:
+		return []Range{{Lower: Bound{Version: v, Inclusive: true}}}
+	case OpLessThanEqual:
+		return []Range{{Upper: Bound{Version: v, Inclusive: true}}}
+	case OpPessimistic:
+		r := Range{Lower: Bound{Version: v, Inclusive: true}}
+		if c.prerelease == PrereleaseStrict && v.Prerelease() != "" {
+			// Only pre-releases of the same segments are allowed, which
+			// all sort before the release of these segments
+			segments := make([]int64, v.si)
+			copy(segments, v.segments)
+			r.Upper = Bound{Version: newVersionFromSegments(segments)}
+		} else if upper := pessimisticUpper(v); upper != nil {
+			r.Upper = Bound{Version: upper}
+		}
+		return []Range{r}
+	default:
+		return []Range{{
+			Lower: Bound{Version: v, Inclusive: true},
+			Upper: Bound{Version: v, Inclusive: true},
+		}}
+	}
+}
+
+// wildcardRanges returns the versions allowed by a wildcard constraint,
+// based on the range matched by the wildcard itself: "1.2.*" matches
+// ">= 1.2.0, < 1.3.0", and "*" matches every version.
+func (c *Constraint) wildcardRanges() []Range {
+	var match Range
+	if c.check.si > 0 {
+		match.Lower = Bound{Version: c.check, Inclusive: true}
+		match.Upper = Bound{Version: incrementLastSegment(c.check.segments[:c.check.si])}
+	}
+
+	switch c.op {
+	case OpNotEqual:
+		return complementRanges([]Range{match})
+	case OpGreaterThan:
+		if match.Upper.Version == nil {
+			return nil
+		}
+		return []Range{{Lower: Bound{Version: match.Upper.Version, Inclusive: true}}}
+	case OpLessThan:
+		if match.Lower.Version == nil {
+			return nil
+		}
+		return []Range{{Upper: Bound{Version: match.Lower.Version}}}
+	case OpGreaterThanEqual:
+		return []Range{{Lower: match.Lower}}
+	case OpLessThanEqual:
+		return []Range{{Upper: match.Upper}}
+	default:
+		return []Range{match}
+	}
+}
+
+// pessimisticUpper returns the exclusive upper bound implied by "~>" for
+// the given version, or nil if the constraint has no upper bound. All but
+// the last specified segment must stay the same, so "~> 1.2.3" is bound
+// by 1.3.0 and "~> 1.2" by 2.0.0, while "~> 1" is unbounded.
+func pessimisticUpper(c *Version) *Version {
+	if c.si < 2 {
+		return nil
+	}
+
+	return incrementLastSegment(c.segments[:c.si-1])
+}
+
+// incrementLastSegment returns a version made of the given segments, with
+// the last one incremented.
+func incrementLastSegment(segments []int64) *Version {
+	result := make([]int64, len(segments))
+	copy(result, segments)
+	result[len(result)-1]++
+
+	return newVersionFromSegments(result)
+}
+
+// newVersionFromSegments returns a version made of the given segments,
+// without pre-release or metadata.
+func newVersionFromSegments(segments []int64) *Version {
+	si := len(segments)
+	for len(segments) < 3 {
+		segments = append(segments, 0)
+	}
+
+	v := &Version{
+		segments: segments,
+		si:       si,
+	}
+	v.original = v.String()
+
+	return v
+}
+
+// intersectRanges returns the ranges contained in both a and b.
+func intersectRanges(a, b []Range) []Range {
+	var result []Range
+	for _, x := range a {
+		for _, y := range b {
+			r := x
+			if compareLower(y.Lower, r.Lower) > 0 {
+				r.Lower = y.Lower
+			}
+			if compareUpper(y.Upper, r.Upper) < 0 {
+				r.Upper = y.Upper
+			}
+			result = append(result, r)
+		}
+	}
+
+	return normalizeRanges(result)
+}
+
+// normalizeRanges sorts the given ranges and merges any of them that
+// overlap or touch, dropping empty ranges.
+func normalizeRanges(rs []Range) []Range {
+	sorted := make([]Range, 0, len(rs))
+	for _, r := range rs {
+		if !r.IsEmpty() {
+			sorted = append(sorted, r)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return compareLower(sorted[i].Lower, sorted[j].Lower) < 0
+	})
+
+	var result []Range
+	for _, r := range sorted {
+		if n := len(result); n > 0 && connected(result[n-1].Upper, r.Lower) {
+			if compareUpper(r.Upper, result[n-1].Upper) > 0 {
+				result[n-1].Upper = r.Upper
+			}
+			continue
+		}
+		result = append(result, r)
+	}
+
+	return result
+}
+
+// connected tests if a range ending at upper and a range starting at
+// lower leave no version between them.
+func connected(upper, lower Bound) bool {
+	if upper.Version == nil || lower.Version == nil {
+		return true
+	}
+
+	cmp := upper.Version.Compare(lower.Version)
+	return cmp > 0 || (cmp == 0 && (upper.Inclusive || lower.Inclusive))
+}
+
+// compareLower orders two lower bounds. A missing version sorts first, and
+// an inclusive bound sorts before an exclusive one on the same version.
+func compareLower(a, b Bound) int {
+	switch {
+	case a.Version == nil && b.Version == nil:
+		return 0
+	case a.Version == nil:
+		return -1
+	case b.Version == nil:
+		return 1
+	}
+
+	if cmp := a.Version.Compare(b.Version); cmp != 0 {
+		return cmp
+	}
+	switch {
+	case a.Inclusive == b.Inclusive:
+		return 0
+	case a.Inclusive:
+		return -1
+	}
+
+	return 1
+}
+
+// compareUpper orders two upper bounds. A missing version sorts last, and
+// an inclusive bound sorts after an exclusive one on the same version.
+func compareUpper(a, b Bound) int {
+	switch {
+	case a.Version == nil && b.Version == nil:
+		return 0
+	case a.Version == nil:
+		return 1
+	case b.Version == nil:
+		return -1
+	}
+
+	if cmp := a.Version.Compare(b.Version); cmp != 0 {
+		return cmp
+	}
+	switch {
+	case a.Inclusive == b.Inclusive:
+		return 0
+	case a.Inclusive:
+		return 1
+	}
+
+	return -1
+}
@@ -0,0 +1,163 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package version
+
+import (
+	"strings"
+	"testing"
+)
+
+func rangesString(rs []Range) string {
+	strs := make([]string, len(rs))
+	for i, r := range rs {
+		strs[i] = r.String()
+	}
+
+	return strings.Join(strs, " || ")
+}
+
+func TestConstraintsRanges(t *testing.T) {
+	cases := []struct {
+		constraint string
+		expected   string
+	}{
+		{">= 1.0, < 2.0", ">= 1.0.0, < 2.0.0"},
+		{"> 1.0, <= 2.0", "> 1.0.0, <= 2.0.0"},
+		{"1.0", "= 1.0.0"},
+		{"= 1.0", "= 1.0.0"},
+		{">= 1.0, <= 1.0", "= 1.0.0"},
+		{"< 1.0", "< 1.0.0"},
+		{">= 1.0, >= 1.5, > 0.5", ">= 1.5.0"},
+		{"~> 1", ">= 1.0.0"},
+		{"~> 1.2", ">= 1.2.0, < 2.0.0"},
+		{"~> 1.2.3", ">= 1.2.3, < 1.3.0"},
+		{"~> 1.0.9.5", ">= 1.0.9.5, < 1.0.10"},
+		{"~> 2.1.0-a", ">= 2.1.0-a, < 2.1.0"},
+		{"!= 1.5", "< 1.5.0 || > 1.5.0"},
+		{"!= 1.5, != 1.5.0", "< 1.5.0 || > 1.5.0"},
+		{"~> 1.2, != 1.5.0", ">= 1.2.0, < 1.5.0 || > 1.5.0, < 2.0.0"},
+		{"!= 1.0, != 2.0", "< 1.0.0 || > 1.0.0, < 2.0.0 || > 2.0.0"},
+		{"> 2.0, < 1.0", ""},
+		{"> 1.0, <= 1.0", ""},
+		{"= 1.0, != 1.0", ""},
+	}
+
+	for _, tc := range cases {
+		c, err := NewConstraint(tc.constraint)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		actual := rangesString(c.Ranges())
+		if actual != tc.expected {
+			t.Fatalf("Constraint: %s\nExpected: %q\nActual: %q",
+				tc.constraint, tc.expected, actual)
+		}
+	}
+}
+
+func TestConstraintsRanges_check(t *testing.T) {
+	constraints := []string{
+		">= 1.0, < 1.2",
+		"~> 1.0",
+		"~> 1.0.7",
+		"~> 1.0.9.5",
+		"!= 1.1.0",
+		"> 1.0, != 1.2.0, <= 2.0",
+	}
+	versions := []string{
+		"0.9", "1.0", "1.0.4", "1.0.7", "1.0.7.5", "1.0.9.4", "1.0.9.5",
+		"1.0.9.5.1", "1.0.10", "1.1", "1.1.0.1", "1.2", "1.9.9", "2.0", "2.0.1",
+	}
+
+	for _, cStr := range constraints {
+		c := MustConstraints(NewConstraint(cStr))
+		ranges := c.Ranges()
+		for _, vStr := range versions {
+			v := Must(NewVersion(vStr))
+
+			inRange := false
+			for _, r := range ranges {
+				if r.Contains(v) {
+					inRange = true
+				}
+			}
+
+			if inRange != c.Check(v) {
+				t.Fatalf("Version: %s\nConstraint: %s\nRanges: %s\nCheck: %t",
+					vStr, cStr, rangesString(ranges), c.Check(v))
+			}
+		}
+	}
+}
+
+func TestRangeString(t *testing.T) {
+	v1 := Must(NewVersion("1.0"))
+	v2 := Must(NewVersion("2.0"))
+
+	cases := []struct {
+		r        Range
+		expected string
+	}{
+		{Range{}, ">= 0.0.0"},
+		{Range{Lower: Bound{Version: v1}}, "> 1.0.0"},
+		{Range{Upper: Bound{Version: v2, Inclusive: true}}, "<= 2.0.0"},
+		{Range{Lower: Bound{Version: v2}, Upper: Bound{Version: v1}}, "< 0.0.0"},
+		{Range{Lower: Bound{Version: v1}, Upper: Bound{Version: v1}}, "< 0.0.0"},
+	}
+
+	for _, tc := range cases {
+		actual := tc.r.String()
+		if actual != tc.expected {
+			t.Fatalf("Expected: %q\nActual: %q", tc.expected, actual)
+		}
+	}
+}
+
+func TestConstraintsIsSubsetOf(t *testing.T) {
+	cases := []struct {
+		constraint string
+		other      string
+		subset     bool
+	}{
+		{"~> 1.2.3", ">= 1.0, < 2.0", true},
+		{">= 1.0, < 2.0", "~> 1.2.3", false},
+		{"~> 1.2", "~> 1.0", true},
+		{"~> 1.0", "~> 1.2", false},
+		{"~> 1.2", ">= 1.2", true},
+		{"~> 1", "~> 1.2", false},
+		{">= 1.0, < 2.0", ">= 1.0, < 2.0", true},
+		{">= 1.0, <= 2.0", ">= 1.0, < 2.0", false},
+		{"> 1.0, < 2.0", ">= 1.0, < 2.0", true},
+		{">= 1.0, < 2.0", "!= 1.5", false},
+		{">= 1.0, < 2.0, != 1.5", "!= 1.5", true},
+		{">= 1.0, < 1.5", "!= 1.5", true},
+		{"= 1.5", "~> 1.4", true},
+		{"= 1.5", "!= 1.5", false},
+		{"> 2.0, < 1.0", "= 1.0", true},
+		{"!= 1.0", "!= 1.0, != 2.0", false},
+		{"!= 1.0, != 2.0", "!= 1.0", true},
+		{"= 1.2.0", "~> 1.2.0-beta", false},
+		{"= 1.2.0-beta.2", "~> 1.2.0-beta", true},
+		{"~> 1.2.0-beta", ">= 1.2.0-alpha, < 1.2.0", true},
+		{"~> 1.2.0-beta", "~> 1.2.0-alpha", true},
+		{"~> 1.2.0-beta", "= 1.2.0-beta", false},
+	}
+
+	for _, tc := range cases {
+		c := MustConstraints(NewConstraint(tc.constraint))
+		other := MustConstraints(NewConstraint(tc.other))
+
+		actual := c.IsSubsetOf(other)
+		if actual != tc.subset {
+			t.Fatalf("Constraint: %s\nOther: %s\nExpected: %#v",
+				tc.constraint, tc.other, tc.subset)
+		}
+
+		if c.Implies(other) != actual {
+			t.Fatalf("Constraint: %s\nOther: %s\nImplies differs from IsSubsetOf",
+				tc.constraint, tc.other)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package version
+
+import (
+	"sort"
+)
+
+// SelectPolicy chooses a version among candidates, which are sorted from
+// lowest to highest. It returns nil if none of them is acceptable.
+type SelectPolicy func(candidates Collection) *Version
+
+// Select returns the version of the collection chosen by the policy among
+// those that satisfy the constraints, or nil if there is none. A nil
+// policy is the same as SelectHighest.
+//
+// Versions that compare equal, such as "1.2.3" and "1.2.3+ent", keep their
+// order from the collection.
+func Select(versions Collection, cs Constraints, policy SelectPolicy) *Version {
+	if policy == nil {
+		policy = SelectHighest
+	}
+
+	candidates := make(Collection, 0, len(versions))
+	for _, v := range versions {
+		if cs.Check(v) {
+			candidates = append(candidates, v)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	sort.Stable(candidates)
+
+	return policy(candidates)
+}
+
+// SelectHighest is a SelectPolicy that chooses the highest version.
+func SelectHighest(candidates Collection) *Version {
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	return candidates[len(candidates)-1]
+}
+
+// SelectLowest is a SelectPolicy that chooses the lowest version, such as
+// to test against the minimum supported version of a dependency.
+func SelectLowest(candidates Collection) *Version {
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	return candidates[0]
+}
+
+// SelectSameMinor returns a SelectPolicy that chooses the highest version
+// with the same major and minor segments as current, i.e. the newest
+// patch release of the installed minor version.
+func SelectSameMinor(current *Version) SelectPolicy {
+	return func(candidates Collection) *Version {
+		for i := len(candidates) - 1; i >= 0; i-- {
+			v := candidates[i]
+			if v.segments[0] == current.segments[0] && v.segments[1] == current.segments[1] {
+				return v
+			}
+		}
+
+		return nil
+	}
+}
+
+// PreferNoMetadata returns a SelectPolicy that ignores versions with
+// metadata, such as "1.2.3+ent", when the same version is also available
+// without metadata, and then chooses with the given policy.
+func PreferNoMetadata(policy SelectPolicy) SelectPolicy {
+	return func(candidates Collection) *Version {
+		preferred := make(Collection, 0, len(candidates))
+		for i, v := range candidates {
+			if v.Metadata() != "" && hasPlainVariant(candidates, i) {
+				continue
+			}
+			preferred = append(preferred, v)
+		}
+
+		return policy(preferred)
+	}
+}
+
+// hasPlainVariant tests if the sorted candidates contain a version without
+// metadata equal to candidates[i].
+func hasPlainVariant(candidates Collection, i int) bool {
+	for j := i - 1; j >= 0 && candidates[j].Equal(candidates[i]); j-- {
+		if candidates[j].Metadata() == "" {
+			return true
+		}
+	}
+	for j := i + 1; j < len(candidates) && candidates[j].Equal(candidates[i]); j++ {
+		if candidates[j].Metadata() == "" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,61 @@
+// Copyright IBM Corp. 2014, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package version
+
+import (
+	"testing"
+)
+
+func TestSelect(t *testing.T) {
+	versionsRaw := []string{
+		"1.4.0", "1.2.3+ent", "1.2.3", "1.2.0", "1.3.1", "1.3.0+ent",
+		"1.3.0", "2.0.0", "1.5.0-beta", "1.2.4+ent",
+	}
+	versions := make(Collection, len(versionsRaw))
+	for i, raw := range versionsRaw {
+		versions[i] = Must(NewVersion(raw))
+	}
+
+	cases := []struct {
+		constraint string
+		policy     SelectPolicy
+		expected   string
+	}{
+		{">= 1.0, < 2.0", nil, "1.4.0"},
+		{">= 1.0, < 2.0", SelectHighest, "1.4.0"},
+		{">= 1.0", SelectHighest, "2.0.0"},
+		{">= 1.0, < 2.0", SelectLowest, "1.2.0"},
+		{"> 1.2.0", SelectLowest, "1.2.3+ent"},
+		{"> 1.2.0", PreferNoMetadata(SelectLowest), "1.2.3"},
+		{"~> 1.2.0", SelectHighest, "1.2.4+ent"},
+		{"~> 1.2.0", PreferNoMetadata(SelectHighest), "1.2.4+ent"},
+		{"~> 1.3.0", SelectLowest, "1.3.0+ent"},
+		{"~> 1.3.0", PreferNoMetadata(SelectLowest), "1.3.0"},
+		{">= 1.0", SelectSameMinor(Must(NewVersion("1.3.0"))), "1.3.1"},
+		{">= 1.0", SelectSameMinor(Must(NewVersion("1.2.3"))), "1.2.4+ent"},
+		{">= 1.0", PreferNoMetadata(SelectSameMinor(Must(NewVersion("1.2.3")))), "1.2.4+ent"},
+		{">= 1.0", SelectSameMinor(Must(NewVersion("1.1.0"))), ""},
+		{"~> 1.3.0", SelectSameMinor(Must(NewVersion("1.2.0"))), ""},
+		{">= 3.0", SelectHighest, ""},
+	}
+
+	for _, tc := range cases {
+		c := MustConstraints(NewConstraint(tc.constraint))
+
+		actual := ""
+		if v := Select(versions, c, tc.policy); v != nil {
+			actual = v.String()
+		}
+
+		if actual != tc.expected {
+			t.Fatalf("Constraint: %s\nExpected: %q\nActual: %q",
+				tc.constraint, tc.expected, actual)
+		}
+	}
+
+	// The collection itself must not be reordered
+	if versions[0].String() != "1.4.0" {
+		t.Fatalf("collection was modified: %s", versions)
+	}
+}